@@ -10,3 +10,12 @@ var DirtFloorPNG []byte
 
 //go:embed sprites/critical_strike.png
 var CriticalStrikePNG []byte
+
+//go:embed sprites/mage.png
+var MagePNG []byte
+
+//go:embed sprites/priest.png
+var PriestPNG []byte
+
+//go:embed sprites/rogue.png
+var RoguePNG []byte
@@ -0,0 +1,208 @@
+package game
+
+import (
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// slotTarget is the kind of target an action bar ability is aimed at
+type slotTarget int
+
+const (
+	targetNone      slotTarget = iota // Self-cast or untargeted abilities
+	targetHostile                     // Requires the current enemy target
+	targetFriendly                    // Uses the current friendly target, or the caster if there is none
+	targetDirection                   // Aimed toward the cursor, such as a cone
+	targetGround                      // Placed on the ground with a targeting reticle
+)
+
+const (
+	actionBarSlotSize    = 40
+	actionBarSlotSpacing = 4
+	actionBarPadding     = 8
+)
+
+// actionBarSlot is an ability or consumable bound to a slot on the action bar
+type actionBarSlot struct {
+	action   string
+	label    string // Shown when the ability has no icon sprite
+	target   slotTarget
+	radius   float64 // Reticle radius for ground-targeted abilities
+	maxRange float64 // Furthest the reticle can be placed from the caster
+	itemID   string  // Consumable used by the slot, for slots the player dragged an item onto
+}
+
+// cooldownKey returns the key in Player.Cooldowns that the slot waits on
+func (slot actionBarSlot) cooldownKey() string {
+	if slot.itemID != "" {
+		return ItemCooldownKey(slot.itemID)
+	}
+	return slot.action
+}
+
+// classActionBars lists each class's abilities in action bar order
+var classActionBars = map[string][]actionBarSlot{
+	"warrior": {
+		{action: "critical_strike", label: "Crit", target: targetHostile},
+		{action: "rend", label: "Rend", target: targetHostile},
+		{action: "battle_shout", label: "Shout"},
+		{action: "taunt", label: "Taunt", target: targetHostile},
+		{action: "cleave", label: "Cleave", target: targetDirection},
+	},
+	"mage": {
+		{action: "frostbolt", label: "Bolt", target: targetHostile},
+		{action: "frost_nova", label: "Nova"},
+		{action: "flamestrike", label: "Flame", target: targetGround, radius: 60, maxRange: 300},
+	},
+	"priest": {
+		{action: "heal", label: "Heal", target: targetFriendly},
+		{action: "renew", label: "Renew", target: targetFriendly},
+		{action: "fade", label: "Fade"},
+		{action: "dispel_magic", label: "Dispel", target: targetHostile},
+	},
+	"rogue": {
+		{action: "sinister_strike", label: "Strike", target: targetHostile},
+		{action: "eviscerate", label: "Evisc", target: targetHostile},
+		{action: "feint", label: "Feint", target: targetHostile},
+	},
+}
+
+// actionBarKeys are the keys bound to each action bar slot, left to right
+var actionBarKeys = []ebiten.Key{
+	ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4,
+	ebiten.Key5, ebiten.Key6, ebiten.Key7, ebiten.Key8,
+}
+
+// localActionBar returns the action bar for the local player's class, with any
+// consumables the player has placed in the slots the class leaves free
+func (g *GameClient) localActionBar() []actionBarSlot {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	localPlayer, exists := g.players[g.localPlayerID]
+	if !exists {
+		return nil
+	}
+
+	slots := make([]actionBarSlot, len(actionBarKeys))
+	copy(slots, classActionBars[localPlayer.Class])
+	for i, itemID := range g.actionBarItems {
+		if i < len(slots) && slots[i].action == "" {
+			slots[i] = actionBarSlot{action: "use_item", itemID: itemID}
+		}
+	}
+
+	return slots
+}
+
+// actionBarBounds returns the screen rectangle of the action bar, centred along the bottom of the screen
+func (g *GameClient) actionBarBounds() (x, y, width, height int) {
+	width = len(actionBarKeys)*actionBarSlotSize + (len(actionBarKeys)-1)*actionBarSlotSpacing + 2*actionBarPadding
+	height = actionBarSlotSize + 2*actionBarPadding
+	return (g.screenWidth - width) / 2, g.screenHeight - height - 10, width, height
+}
+
+// actionBarSlotBounds returns the top left corner of an action bar slot
+func (g *GameClient) actionBarSlotBounds(index int) (x, y int) {
+	barX, barY, _, _ := g.actionBarBounds()
+	return barX + actionBarPadding + index*(actionBarSlotSize+actionBarSlotSpacing), barY + actionBarPadding
+}
+
+// actionBarSlotAt returns the index of the action bar slot under a screen point, or -1
+func (g *GameClient) actionBarSlotAt(x, y int) int {
+	for i := range actionBarKeys {
+		slotX, slotY := g.actionBarSlotBounds(i)
+		if pointInRect(x, y, slotX, slotY, actionBarSlotSize, actionBarSlotSize) {
+			return i
+		}
+	}
+	return -1
+}
+
+// placeActionBarItem puts a consumable on an action bar slot the class has not filled with an ability
+func (g *GameClient) placeActionBarItem(index int, itemID string) {
+	slots := g.localActionBar()
+	if index < 0 || index >= len(slots) || (slots[index].action != "" && slots[index].itemID == "") {
+		g.addMessage("That action bar slot is already in use")
+		return
+	}
+
+	g.mutex.Lock()
+	g.actionBarItems[index] = itemID
+	g.mutex.Unlock()
+}
+
+// handleActionBarClick uses consumables clicked on the action bar and clears them
+// from their slot on right-click. It returns true when the click was on an item slot.
+func (g *GameClient) handleActionBarClick() bool {
+	leftClick := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	rightClick := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight)
+	if !leftClick && !rightClick {
+		return false
+	}
+
+	index := g.actionBarSlotAt(ebiten.CursorPosition())
+	slots := g.localActionBar()
+	if index < 0 || index >= len(slots) || slots[index].itemID == "" {
+		return false
+	}
+
+	if rightClick {
+		g.mutex.Lock()
+		delete(g.actionBarItems, index)
+		g.mutex.Unlock()
+		return true
+	}
+
+	g.useItem(slots[index].itemID)
+	return true
+}
+
+func (g *GameClient) useItem(itemID string) {
+	g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+		"action": "use_item",
+		"target": itemID,
+	})
+}
+
+// handleActionBarInput sends the ability bound to any action bar key pressed this frame
+func (g *GameClient) handleActionBarInput() {
+	slots := g.localActionBar()
+
+	for i, key := range actionBarKeys {
+		if i >= len(slots) || slots[i].action == "" || !inpututil.IsKeyJustPressed(key) {
+			continue
+		}
+
+		slot := slots[i]
+		if slot.itemID != "" {
+			g.useItem(slot.itemID)
+			continue
+		}
+
+		actionData := map[string]interface{}{
+			"action": slot.action,
+		}
+
+		switch slot.target {
+		case targetHostile:
+			if g.targetEnemyID == "" {
+				continue
+			}
+			actionData["target"] = g.targetEnemyID
+		case targetFriendly:
+			if g.targetFriendlyID != "" {
+				actionData["target"] = g.targetFriendlyID
+			}
+		case targetDirection:
+			actionData["x"], actionData["y"] = g.cursorWorldPosition()
+		case targetGround:
+			// Wait for the player to place the reticle
+			g.groundTargetSlot = &slot
+			continue
+		}
+
+		g.sendMessage(types.MsgPlayerAction, actionData)
+	}
+}
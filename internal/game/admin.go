@@ -0,0 +1,62 @@
+package game
+
+import "github.com/CollinEMac/tarnation/internal/types"
+
+// AdminCommandDefinition describes a staff command and who may run it
+type AdminCommandDefinition struct {
+	Name  string
+	Usage string
+	Role  types.Role // Lowest role allowed to run the command
+}
+
+var adminCommands = map[string]AdminCommandDefinition{
+	"spawn": {
+		Name:  "spawn",
+		Usage: "/spawn <enemy type> [name]",
+		Role:  types.RoleGM,
+	},
+	"teleport": {
+		Name:  "teleport",
+		Usage: "/teleport <player> [<x> <y>]",
+		Role:  types.RoleGM,
+	},
+	"sethealth": {
+		Name:  "sethealth",
+		Usage: "/sethealth <player> <health>",
+		Role:  types.RoleGM,
+	},
+	"kick": {
+		Name:  "kick",
+		Usage: "/kick <player>",
+		Role:  types.RoleGM,
+	},
+	"announce": {
+		Name:  "announce",
+		Usage: "/announce <message>",
+		Role:  types.RoleGM,
+	},
+	"reload": {
+		Name:  "reload",
+		Usage: "/reload",
+		Role:  types.RoleAdmin,
+	},
+}
+
+// roleRanks orders the roles so a higher role can do everything a lower one can
+var roleRanks = map[types.Role]int{
+	types.RolePlayer: 0,
+	types.RoleGM:     1,
+	types.RoleAdmin:  2,
+}
+
+// LookupAdminCommand returns the definition of a staff command
+func LookupAdminCommand(name string) (AdminCommandDefinition, bool) {
+	command, exists := adminCommands[name]
+	return command, exists
+}
+
+// HasRole reports whether a role is at least as privileged as the required one
+func HasRole(role, required types.Role) bool {
+	rank, known := roleRanks[role]
+	return known && rank >= roleRanks[required]
+}
@@ -0,0 +1,229 @@
+package game
+
+import (
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	defaultAggroRadius = 100.0 // Pixels within which idle enemies notice players
+	defaultWalkSpeed   = 1.0   // Pixels per tick while idle or patrolling
+	defaultChaseSpeed  = 2.0   // Pixels per tick while chasing or evading
+	defaultFleeSpeed   = 1.5   // Pixels per tick while fleeing, slow enough to be caught
+	fleeDistance       = 100.0 // How far ahead of itself a fleeing enemy aims
+)
+
+// AIConfig selects and tunes an enemy template's behaviors. Optional behaviors
+// are switched on by giving them a non-zero value.
+type AIConfig struct {
+	AggroRadius       float64       // Pixels within which idle enemies notice players
+	LeashDistance     float64       // Distance from spawn at which the enemy evades; 0 never leashes
+	FleeBelow         float64       // Health fraction at which the enemy flees; 0 never flees
+	FleeDuration      time.Duration // How long a flight lasts before the enemy turns to fight
+	CallForHelpRadius float64       // Allies within this range join in when the enemy is pulled or flees
+	WalkSpeed         float64
+	ChaseSpeed        float64
+	FleeSpeed         float64
+}
+
+// AITarget is where an enemy's current target stands
+type AITarget struct {
+	ID string
+	X  float64
+	Y  float64
+}
+
+// AIWorld is everything the enemy state machine needs from the game world. The
+// server implements it over live state; tests can drive an enemy with a fake.
+type AIWorld interface {
+	Now() time.Time
+	Rand() RNG
+
+	// AcquireTarget adds threat from players within radius and reports whether the enemy now has a target
+	AcquireTarget(enemy *types.Enemy, radius float64) bool
+	// Target returns the enemy's living target, re-picking from the threat list if the old one is gone
+	Target(enemy *types.Enemy) (AITarget, bool)
+	CanSee(enemy *types.Enemy, x, y float64) bool
+	// MoveToward steps the enemy toward a point and reports false if a wall stopped it entirely
+	MoveToward(enemy *types.Enemy, x, y, speed float64) bool
+
+	// Attack swings at the target if the enemy's weapon is ready
+	Attack(enemy *types.Enemy, target AITarget)
+	// UseAbilities advances boss scripts and lets the enemy use its special abilities
+	UseAbilities(enemy *types.Enemy, target AITarget, distance float64)
+	// CallForHelp pulls idle allies within radius into the enemy's fight
+	CallForHelp(enemy *types.Enemy, radius float64)
+
+	// Evade drops the enemy's threat and resets any encounter as it starts heading home
+	Evade(enemy *types.Enemy)
+	// FinishEvade restores the enemy to full health once it is back at its spawn point
+	FinishEvade(enemy *types.Enemy)
+}
+
+// EnemyAI returns the behavior configuration for an enemy type with defaults filled in
+func EnemyAI(enemyType string) AIConfig {
+	config := enemyTemplates[enemyType].AI
+	if config.AggroRadius == 0 {
+		config.AggroRadius = defaultAggroRadius
+	}
+	if config.WalkSpeed == 0 {
+		config.WalkSpeed = defaultWalkSpeed
+	}
+	if config.ChaseSpeed == 0 {
+		config.ChaseSpeed = defaultChaseSpeed
+	}
+	if config.FleeSpeed == 0 {
+		config.FleeSpeed = defaultFleeSpeed
+	}
+	return config
+}
+
+// RunAI advances an enemy's state machine by one tick
+func RunAI(world AIWorld, enemy *types.Enemy) {
+	config := EnemyAI(enemy.EnemyType)
+	if enemy.AIState == "" {
+		enemy.AIState = restingState(enemy)
+	}
+
+	var next types.AIState
+	switch enemy.AIState {
+	case types.AIIdle, types.AIPatrol:
+		next = aiRest(world, enemy, config)
+	case types.AICallForHelp:
+		world.CallForHelp(enemy, config.CallForHelpRadius)
+		next = types.AIChase
+	case types.AIChase, types.AIAttack:
+		next = aiFight(world, enemy, config)
+	case types.AIFlee:
+		next = aiFlee(world, enemy, config)
+	case types.AIEvade:
+		next = aiEvade(world, enemy, config)
+	default:
+		next = restingState(enemy)
+	}
+
+	if next != enemy.AIState {
+		enterAIState(world, enemy, config, next)
+	}
+}
+
+// enterAIState switches states, running the one-off actions that come with entering the new one
+func enterAIState(world AIWorld, enemy *types.Enemy, config AIConfig, state types.AIState) {
+	enemy.AIState = state
+
+	switch state {
+	case types.AIFlee:
+		enemy.HasFled = true
+		enemy.FleeUntil = world.Now().Add(config.FleeDuration)
+		if config.CallForHelpRadius > 0 {
+			world.CallForHelp(enemy, config.CallForHelpRadius)
+		}
+	case types.AIEvade:
+		enemy.HasFled = false
+		world.Evade(enemy)
+	}
+}
+
+// restingState is the out of combat state for an enemy's idle behavior
+func restingState(enemy *types.Enemy) types.AIState {
+	if enemy.Idle.Kind == types.IdlePatrol {
+		return types.AIPatrol
+	}
+	return types.AIIdle
+}
+
+// aiRest walks the enemy's idle route until a player comes close or attacks it
+func aiRest(world AIWorld, enemy *types.Enemy, config AIConfig) types.AIState {
+	if enemy.TargetID != "" || world.AcquireTarget(enemy, config.AggroRadius) {
+		if config.CallForHelpRadius > 0 {
+			return types.AICallForHelp
+		}
+		return types.AIChase
+	}
+
+	now := world.Now()
+	destination, moving := IdleDestination(world.Rand(), enemy, now)
+	if moving && !world.MoveToward(enemy, destination.X, destination.Y, config.WalkSpeed) {
+		SkipIdleDestination(enemy, now)
+	}
+	return restingState(enemy)
+}
+
+// aiFight chases the target until it is in reach and visible, then attacks it
+func aiFight(world AIWorld, enemy *types.Enemy, config AIConfig) types.AIState {
+	target, ok := world.Target(enemy)
+	if !ok || leashed(enemy, config) {
+		return types.AIEvade
+	}
+
+	if shouldFlee(enemy, config) {
+		return types.AIFlee
+	}
+
+	dx := target.X - enemy.X
+	dy := target.Y - enemy.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+
+	// Ranged enemies close in until they are in reach and can see their target
+	state := types.AIAttack
+	canSee := !IsRangedWeapon(enemy.Weapon) || world.CanSee(enemy, target.X, target.Y)
+	if distance > WeaponReach(enemy.Weapon) || !canSee {
+		world.MoveToward(enemy, target.X, target.Y, config.ChaseSpeed)
+		state = types.AIChase
+	} else {
+		world.Attack(enemy, target)
+	}
+
+	world.UseAbilities(enemy, target, distance)
+	return state
+}
+
+// aiFlee runs directly away from the target until the flight is over
+func aiFlee(world AIWorld, enemy *types.Enemy, config AIConfig) types.AIState {
+	target, ok := world.Target(enemy)
+	if !ok {
+		return types.AIEvade
+	}
+	if !world.Now().Before(enemy.FleeUntil) {
+		return types.AIChase
+	}
+
+	dx := enemy.X - target.X
+	dy := enemy.Y - target.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance == 0 {
+		dx, distance = 1, 1
+	}
+
+	world.MoveToward(enemy, enemy.X+dx/distance*fleeDistance, enemy.Y+dy/distance*fleeDistance, config.FleeSpeed)
+	return types.AIFlee
+}
+
+// aiEvade walks the enemy home, ignoring players, and restores it on arrival.
+// An enemy that cannot find its way around a wall is put straight back.
+func aiEvade(world AIWorld, enemy *types.Enemy, config AIConfig) types.AIState {
+	home := types.Point{X: enemy.SpawnX, Y: enemy.SpawnY}
+	if !reachedPoint(enemy, home) && world.MoveToward(enemy, home.X, home.Y, config.ChaseSpeed) {
+		return types.AIEvade
+	}
+
+	enemy.X, enemy.Y = home.X, home.Y
+	world.FinishEvade(enemy)
+	return restingState(enemy)
+}
+
+func leashed(enemy *types.Enemy, config AIConfig) bool {
+	if config.LeashDistance <= 0 {
+		return false
+	}
+	dx := enemy.X - enemy.SpawnX
+	dy := enemy.Y - enemy.SpawnY
+	return math.Sqrt(dx*dx+dy*dy) > config.LeashDistance
+}
+
+func shouldFlee(enemy *types.Enemy, config AIConfig) bool {
+	return config.FleeBelow > 0 && !enemy.HasFled && enemy.MaxHealth > 0 &&
+		float64(enemy.Health)/float64(enemy.MaxHealth) <= config.FleeBelow
+}
@@ -0,0 +1,286 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// fakeAIWorld is an AIWorld with a single player who can be placed anywhere. It
+// records which world calls the state machine makes.
+type fakeAIWorld struct {
+	now      time.Time
+	rng      *rand.Rand
+	target   *AITarget // Nil when the enemy has nobody to fight
+	hasAggro bool      // Whether AcquireTarget finds the player
+	calls    []string
+}
+
+func newFakeAIWorld() *fakeAIWorld {
+	return &fakeAIWorld{
+		now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		rng: rand.New(rand.NewSource(1)),
+	}
+}
+
+func (w *fakeAIWorld) Now() time.Time { return w.now }
+func (w *fakeAIWorld) Rand() RNG      { return w.rng }
+
+func (w *fakeAIWorld) AcquireTarget(enemy *types.Enemy, radius float64) bool {
+	if !w.hasAggro || w.target == nil {
+		return false
+	}
+	enemy.TargetID = w.target.ID
+	return true
+}
+
+func (w *fakeAIWorld) Target(enemy *types.Enemy) (AITarget, bool) {
+	if w.target == nil {
+		enemy.TargetID = ""
+		return AITarget{}, false
+	}
+	return *w.target, true
+}
+
+func (w *fakeAIWorld) CanSee(enemy *types.Enemy, x, y float64) bool { return true }
+
+func (w *fakeAIWorld) MoveToward(enemy *types.Enemy, x, y, speed float64) bool {
+	w.calls = append(w.calls, "move")
+	dx := x - enemy.X
+	dy := y - enemy.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance <= speed {
+		enemy.X, enemy.Y = x, y
+	} else {
+		enemy.X += dx / distance * speed
+		enemy.Y += dy / distance * speed
+	}
+	return true
+}
+
+func (w *fakeAIWorld) Attack(enemy *types.Enemy, target AITarget) {
+	w.calls = append(w.calls, "attack")
+}
+
+func (w *fakeAIWorld) UseAbilities(enemy *types.Enemy, target AITarget, distance float64) {}
+
+func (w *fakeAIWorld) CallForHelp(enemy *types.Enemy, radius float64) {
+	w.calls = append(w.calls, "call_for_help")
+}
+
+func (w *fakeAIWorld) Evade(enemy *types.Enemy) {
+	w.calls = append(w.calls, "evade")
+	enemy.TargetID = ""
+}
+
+func (w *fakeAIWorld) FinishEvade(enemy *types.Enemy) {
+	w.calls = append(w.calls, "finish_evade")
+	enemy.Health = enemy.MaxHealth
+}
+
+// tick runs one AI step and returns the calls the state machine made during it
+func (w *fakeAIWorld) tick(enemy *types.Enemy) []string {
+	w.calls = nil
+	RunAI(w, enemy)
+	return w.calls
+}
+
+// withTestEnemy registers an enemy type that uses every optional AI behavior
+func withTestEnemy(t *testing.T) string {
+	t.Helper()
+	const enemyType = "ai_test"
+	enemyTemplates[enemyType] = EnemyTemplate{
+		Name: "Test Dummy",
+		Weapon: types.Weapon{
+			Name:       "Claws",
+			Range:      1,
+			WeaponType: "melee",
+		},
+		AI: AIConfig{
+			LeashDistance:     300,
+			FleeBelow:         0.2,
+			FleeDuration:      3 * time.Second,
+			CallForHelpRadius: 150,
+		},
+	}
+	t.Cleanup(func() { delete(enemyTemplates, enemyType) })
+	return enemyType
+}
+
+func newTestEnemy(enemyType string, idle types.IdleBehavior) *types.Enemy {
+	template := enemyTemplates[enemyType]
+	return &types.Enemy{
+		ID:        "enemy",
+		EnemyType: enemyType,
+		X:         100,
+		Y:         100,
+		SpawnX:    100,
+		SpawnY:    100,
+		Health:    100,
+		MaxHealth: 100,
+		Weapon:    &template.Weapon,
+		Idle:      idle,
+	}
+}
+
+func expectState(t *testing.T, enemy *types.Enemy, want types.AIState) {
+	t.Helper()
+	if enemy.AIState != want {
+		t.Fatalf("AI state = %q, want %q", enemy.AIState, want)
+	}
+}
+
+func expectCall(t *testing.T, calls []string, call string, want bool) {
+	t.Helper()
+	if slices.Contains(calls, call) != want {
+		t.Fatalf("calls %v: %s made = %v, want %v", calls, call, !want, want)
+	}
+}
+
+func TestRunAIFightFleeAndEvade(t *testing.T) {
+	enemyType := withTestEnemy(t)
+	world := newFakeAIWorld()
+	enemy := newTestEnemy(enemyType, types.IdleBehavior{Kind: types.IdleStationary})
+
+	// Nobody nearby: the enemy stays put
+	calls := world.tick(enemy)
+	expectState(t, enemy, types.AIIdle)
+	expectCall(t, calls, "call_for_help", false)
+
+	// A player walks into aggro range
+	world.target = &AITarget{ID: "player", X: 180, Y: 100}
+	world.hasAggro = true
+	world.tick(enemy)
+	expectState(t, enemy, types.AICallForHelp)
+	if enemy.TargetID != "player" {
+		t.Fatalf("target = %q, want player", enemy.TargetID)
+	}
+
+	calls = world.tick(enemy)
+	expectState(t, enemy, types.AIChase)
+	expectCall(t, calls, "call_for_help", true)
+
+	// Out of reach it closes in rather than attacking
+	calls = world.tick(enemy)
+	expectState(t, enemy, types.AIChase)
+	expectCall(t, calls, "move", true)
+	expectCall(t, calls, "attack", false)
+
+	// In reach it attacks
+	world.target.X = enemy.X + 10
+	calls = world.tick(enemy)
+	expectState(t, enemy, types.AIAttack)
+	expectCall(t, calls, "attack", true)
+
+	// Dropping below FleeBelow sends it running, calling for help as it goes
+	enemy.Health = 15
+	calls = world.tick(enemy)
+	expectState(t, enemy, types.AIFlee)
+	expectCall(t, calls, "call_for_help", true)
+	if !enemy.HasFled {
+		t.Fatal("HasFled not set on fleeing")
+	}
+
+	before := math.Abs(enemy.X - world.target.X)
+	calls = world.tick(enemy)
+	expectState(t, enemy, types.AIFlee)
+	expectCall(t, calls, "attack", false)
+	if after := math.Abs(enemy.X - world.target.X); after <= before {
+		t.Fatalf("fleeing enemy moved from %.1f to %.1f away from its target", before, after)
+	}
+
+	// Once the flight is over it turns back to fight
+	world.now = enemy.FleeUntil
+	world.tick(enemy)
+	expectState(t, enemy, types.AIChase)
+
+	// It only flees once per fight, even though its health is still low
+	world.target.X = enemy.X + 10
+	calls = world.tick(enemy)
+	expectState(t, enemy, types.AIAttack)
+	expectCall(t, calls, "attack", true)
+
+	// Dragged past its leash, it gives up and evades
+	enemy.X = enemy.SpawnX + 350
+	world.target.X = enemy.X + 10
+	calls = world.tick(enemy)
+	expectState(t, enemy, types.AIEvade)
+	expectCall(t, calls, "evade", true)
+	expectCall(t, calls, "attack", false)
+	if enemy.HasFled {
+		t.Fatal("HasFled not reset on evading")
+	}
+
+	// Evading ignores players until it is home, then it is restored
+	for i := 0; i < 1000 && enemy.AIState == types.AIEvade; i++ {
+		calls = world.tick(enemy)
+		expectCall(t, calls, "attack", false)
+	}
+	expectState(t, enemy, types.AIIdle)
+	expectCall(t, calls, "finish_evade", true)
+	if enemy.X != enemy.SpawnX || enemy.Y != enemy.SpawnY {
+		t.Fatalf("evaded to (%.1f, %.1f), want spawn (%.1f, %.1f)", enemy.X, enemy.Y, enemy.SpawnX, enemy.SpawnY)
+	}
+	if enemy.Health != enemy.MaxHealth {
+		t.Fatalf("health after evading = %d, want %d", enemy.Health, enemy.MaxHealth)
+	}
+
+	// A fresh fight can end in flight again
+	world.tick(enemy)
+	world.tick(enemy)
+	enemy.Health = 10
+	world.tick(enemy)
+	expectState(t, enemy, types.AIFlee)
+}
+
+func TestRunAILosingTargetReturnsToPatrol(t *testing.T) {
+	enemyType := withTestEnemy(t)
+	world := newFakeAIWorld()
+	enemy := newTestEnemy(enemyType, types.IdleBehavior{
+		Kind:      types.IdlePatrol,
+		Waypoints: []types.Point{{X: 100, Y: 100}, {X: 200, Y: 100}},
+	})
+
+	world.tick(enemy)
+	expectState(t, enemy, types.AIPatrol)
+
+	// Attacked from range, so the enemy already has a target without acquiring one
+	world.target = &AITarget{ID: "player", X: 150, Y: 150}
+	enemy.TargetID = "player"
+	world.tick(enemy)
+	expectState(t, enemy, types.AICallForHelp)
+	world.tick(enemy)
+	expectState(t, enemy, types.AIChase)
+
+	// The target dies or leaves, so there is nothing left to fight
+	world.target = nil
+	calls := world.tick(enemy)
+	expectState(t, enemy, types.AIEvade)
+	expectCall(t, calls, "evade", true)
+
+	for i := 0; i < 1000 && enemy.AIState == types.AIEvade; i++ {
+		calls = world.tick(enemy)
+	}
+	expectState(t, enemy, types.AIPatrol)
+	expectCall(t, calls, "finish_evade", true)
+}
+
+func TestRunAIFleesFromTargetWithoutCallForHelp(t *testing.T) {
+	// The basic enemy flees but has no allies to call
+	world := newFakeAIWorld()
+	enemy := newTestEnemy("basic", types.IdleBehavior{Kind: types.IdleStationary})
+	world.target = &AITarget{ID: "player", X: 110, Y: 100}
+	world.hasAggro = true
+
+	world.tick(enemy)
+	expectState(t, enemy, types.AIChase)
+
+	enemy.Health = 10
+	calls := world.tick(enemy)
+	expectState(t, enemy, types.AIFlee)
+	expectCall(t, calls, "call_for_help", false)
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"math"
+	"sort"
+)
+
+// AreaShape is the footprint of an area-of-effect ability
+type AreaShape int
+
+const (
+	AreaCircle AreaShape = iota // Everything within Radius of the origin
+	AreaCone                    // Everything within Radius and HalfAngle of Facing
+)
+
+// Area is an area-of-effect footprint in world coordinates
+type Area struct {
+	Shape     AreaShape
+	X, Y      float64 // Origin: the caster, or the ground point for ground-targeted spells
+	Radius    float64
+	Facing    float64 // Cone direction in radians
+	HalfAngle float64 // Cone half-width in radians
+}
+
+// AreaTarget is an entity that could be caught in an area
+type AreaTarget struct {
+	ID   string
+	X, Y float64
+}
+
+// ConeToward returns a cone from the origin pointing at a world position
+func ConeToward(x, y, towardX, towardY, radius, halfAngle float64) Area {
+	return Area{
+		Shape:     AreaCone,
+		X:         x,
+		Y:         y,
+		Radius:    radius,
+		Facing:    math.Atan2(towardY-y, towardX-x),
+		HalfAngle: halfAngle,
+	}
+}
+
+// Contains reports whether a point lies inside the area
+func (a Area) Contains(x, y float64) bool {
+	dx := x - a.X
+	dy := y - a.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance > a.Radius {
+		return false
+	}
+
+	if a.Shape != AreaCone || distance == 0 {
+		return true
+	}
+
+	// Angle between the facing and the point, wrapped to [-pi, pi]
+	offset := math.Atan2(dy, dx) - a.Facing
+	offset = math.Atan2(math.Sin(offset), math.Cos(offset))
+	return math.Abs(offset) <= a.HalfAngle
+}
+
+// SelectAreaTargets returns the IDs of up to maxTargets candidates inside the area,
+// nearest to the origin first. A maxTargets of 0 means no cap.
+func SelectAreaTargets(area Area, candidates []AreaTarget, maxTargets int) []string {
+	var inside []AreaTarget
+	for _, candidate := range candidates {
+		if area.Contains(candidate.X, candidate.Y) {
+			inside = append(inside, candidate)
+		}
+	}
+
+	distance := func(t AreaTarget) float64 {
+		return math.Hypot(t.X-area.X, t.Y-area.Y)
+	}
+	sort.Slice(inside, func(i, j int) bool { return distance(inside[i]) < distance(inside[j]) })
+
+	if maxTargets > 0 && len(inside) > maxTargets {
+		inside = inside[:maxTargets]
+	}
+
+	ids := make([]string, len(inside))
+	for i, target := range inside {
+		ids[i] = target.ID
+	}
+	return ids
+}
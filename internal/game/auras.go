@@ -0,0 +1,157 @@
+package game
+
+import (
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// auraTemplates defines every aura that can be applied in the game, keyed by aura ID
+var auraTemplates = map[string]types.Aura{
+	"rend": {
+		ID:           "rend",
+		Name:         "Rend",
+		Harmful:      true,
+		Duration:     15 * time.Second,
+		MaxStacks:    1,
+		TickInterval: 3 * time.Second,
+		TickDamage:   3,
+	},
+	"battle_shout": {
+		ID:         "battle_shout",
+		Name:       "Battle Shout",
+		DispelType: types.DispelMagic,
+		Duration:   2 * time.Minute,
+		MaxStacks:  1,
+		Modifiers:  types.StatModifiers{Strength: 5},
+	},
+	"renew": {
+		ID:           "renew",
+		Name:         "Renew",
+		DispelType:   types.DispelMagic,
+		Duration:     12 * time.Second,
+		MaxStacks:    1,
+		TickInterval: 3 * time.Second,
+		TickHeal:     6,
+	},
+	"minor_fortitude": {
+		ID:        "minor_fortitude",
+		Name:      "Minor Fortitude",
+		Duration:  30 * time.Minute,
+		MaxStacks: 1,
+		Modifiers: types.StatModifiers{Stamina: 4},
+	},
+	"frenzy": {
+		ID:         "frenzy",
+		Name:       "Frenzy",
+		DispelType: types.DispelMagic,
+		Duration:   15 * time.Second,
+		MaxStacks:  1,
+		Modifiers:  types.StatModifiers{Strength: 10},
+	},
+	"enrage": {
+		ID:        "enrage",
+		Name:      "Enrage",
+		Duration:  10 * time.Minute,
+		MaxStacks: 1,
+		Modifiers: types.StatModifiers{Strength: 40, Agility: 10},
+	},
+}
+
+// NewAura creates a fresh instance of the aura template with the given ID
+func NewAura(auraID, sourceID string, now time.Time) (*types.Aura, bool) {
+	template, exists := auraTemplates[auraID]
+	if !exists {
+		return nil, false
+	}
+
+	aura := template
+	aura.SourceID = sourceID
+	aura.Stacks = 1
+	aura.ExpiresAt = now.Add(aura.Duration).UnixMilli()
+	if aura.TickInterval > 0 {
+		aura.NextTick = now.Add(aura.TickInterval)
+	}
+
+	return &aura, true
+}
+
+// ApplyAura adds an aura to the list. Reapplying an aura from the same source
+// refreshes its duration and adds a stack up to MaxStacks.
+func ApplyAura(auras []*types.Aura, aura *types.Aura) []*types.Aura {
+	for _, existing := range auras {
+		if existing.ID == aura.ID && existing.SourceID == aura.SourceID {
+			existing.ExpiresAt = aura.ExpiresAt
+			if existing.Stacks < existing.MaxStacks {
+				existing.Stacks++
+			}
+			return auras
+		}
+	}
+
+	return append(auras, aura)
+}
+
+// TickAura advances a periodic aura's timer, returning the damage and healing
+// due for every tick that has elapsed before the aura expires
+func TickAura(aura *types.Aura, now time.Time) (damage, heal int) {
+	if aura.TickInterval <= 0 {
+		return 0, 0
+	}
+
+	for !now.Before(aura.NextTick) && aura.NextTick.UnixMilli() <= aura.ExpiresAt {
+		damage += aura.TickDamage * aura.Stacks
+		heal += aura.TickHeal * aura.Stacks
+		aura.NextTick = aura.NextTick.Add(aura.TickInterval)
+	}
+
+	return damage, heal
+}
+
+// ExpireAuras removes auras whose duration has run out, returning the remaining and expired auras
+func ExpireAuras(auras []*types.Aura, now time.Time) ([]*types.Aura, []*types.Aura) {
+	var remaining, expired []*types.Aura
+	nowMillis := now.UnixMilli()
+
+	for _, aura := range auras {
+		if aura.ExpiresAt <= nowMillis {
+			expired = append(expired, aura)
+		} else {
+			remaining = append(remaining, aura)
+		}
+	}
+
+	return remaining, expired
+}
+
+// DispelAuras removes up to count auras of the given dispel type and harmfulness,
+// returning the remaining and dispelled auras. A count of 0 removes every match.
+func DispelAuras(auras []*types.Aura, dispelType types.DispelType, harmful bool, count int) ([]*types.Aura, []*types.Aura) {
+	var remaining, dispelled []*types.Aura
+
+	for _, aura := range auras {
+		matches := dispelType != types.DispelNone && aura.DispelType == dispelType && aura.Harmful == harmful
+		if matches && (count == 0 || len(dispelled) < count) {
+			dispelled = append(dispelled, aura)
+		} else {
+			remaining = append(remaining, aura)
+		}
+	}
+
+	return remaining, dispelled
+}
+
+// TotalStatModifiers sums the stat modifiers of every aura, multiplied by its stacks
+func TotalStatModifiers(auras []*types.Aura) types.StatModifiers {
+	var total types.StatModifiers
+
+	for _, aura := range auras {
+		total.Strength += aura.Modifiers.Strength * aura.Stacks
+		total.Agility += aura.Modifiers.Agility * aura.Stacks
+		total.Intellect += aura.Modifiers.Intellect * aura.Stacks
+		total.Stamina += aura.Modifiers.Stamina * aura.Stacks
+		total.Armor += aura.Modifiers.Armor * aura.Stacks
+	}
+
+	return total
+}
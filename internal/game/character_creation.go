@@ -0,0 +1,172 @@
+package game
+
+import (
+	"image/color"
+	"os"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	creationButtonWidth  = 160
+	creationButtonHeight = 30
+	creationMaxNameRunes = 16
+
+	staffTokenEnv = "TARNATION_TOKEN" // Staff log in with the token the server gave them
+)
+
+// classButtonBounds returns the screen rectangle of a class choice on the creation screen
+func (g *GameClient) classButtonBounds(index int) (x, y, width, height int) {
+	return (g.screenWidth - creationButtonWidth) / 2, 220 + index*(creationButtonHeight+10), creationButtonWidth, creationButtonHeight
+}
+
+// enterWorldButtonBounds returns the screen rectangle of the Enter World button
+func (g *GameClient) enterWorldButtonBounds() (x, y, width, height int) {
+	_, lastY, _, _ := g.classButtonBounds(len(PlayableClasses()))
+	return (g.screenWidth - creationButtonWidth) / 2, lastY + 20, creationButtonWidth, creationButtonHeight
+}
+
+// handleCharacterCreationInput lets the player type a name, pick a class and enter the world
+func (g *GameClient) handleCharacterCreationInput() {
+	g.mutex.RLock()
+	requested := g.characterRequested
+	g.mutex.RUnlock()
+	if requested {
+		return
+	}
+
+	g.creationName = ebiten.AppendInputChars(g.creationName)
+	if len(g.creationName) > creationMaxNameRunes {
+		g.creationName = g.creationName[:creationMaxNameRunes]
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(g.creationName) > 0 {
+		g.creationName = g.creationName[:len(g.creationName)-1]
+	}
+
+	classes := PlayableClasses()
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
+		g.creationClassIndex = (g.creationClassIndex + 1) % len(classes)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
+		g.creationClassIndex = (g.creationClassIndex + len(classes) - 1) % len(classes)
+	}
+
+	enterWorld := inpututil.IsKeyJustPressed(ebiten.KeyEnter)
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mouseX, mouseY := ebiten.CursorPosition()
+		for i := range classes {
+			x, y, width, height := g.classButtonBounds(i)
+			if pointInRect(mouseX, mouseY, x, y, width, height) {
+				g.creationClassIndex = i
+			}
+		}
+		x, y, width, height := g.enterWorldButtonBounds()
+		if pointInRect(mouseX, mouseY, x, y, width, height) {
+			enterWorld = true
+		}
+	}
+
+	if enterWorld {
+		createData := map[string]string{
+			"class": classes[g.creationClassIndex],
+			"name":  string(g.creationName),
+		}
+		if token := os.Getenv(staffTokenEnv); token != "" {
+			createData["token"] = token
+		}
+
+		err := g.sendMessage(types.MsgCharacterCreate, createData)
+		if err == nil {
+			g.mutex.Lock()
+			g.characterRequested = true
+			g.creationError = ""
+			g.mutex.Unlock()
+		}
+	}
+}
+
+func (g *GameClient) drawCharacterCreation(screen *ebiten.Image) {
+	classes := PlayableClasses()
+
+	opts := &text.DrawOptions{}
+	opts.GeoM.Translate(float64(g.screenWidth/2-70), 120)
+	text.Draw(screen, "Create your character", g.fontFace, opts)
+
+	nameX, nameY := (g.screenWidth-creationButtonWidth)/2, 160
+	ebitenutil.DrawRect(screen, float64(nameX), float64(nameY), creationButtonWidth, 24, color.RGBA{0x10, 0x10, 0x10, 0xFF})
+	drawRectBorder(screen, nameX, nameY, creationButtonWidth, 24, color.RGBA{0x80, 0x80, 0x80, 0xFF})
+
+	nameText := string(g.creationName)
+	if nameText == "" {
+		nameText = "Type a name..."
+	}
+	opts = &text.DrawOptions{}
+	opts.GeoM.Translate(float64(nameX+6), float64(nameY+5))
+	text.Draw(screen, nameText, g.fontFace, opts)
+
+	for i, class := range classes {
+		x, y, width, height := g.classButtonBounds(i)
+
+		buttonColor := color.RGBA{0x30, 0x30, 0x30, 0xFF}
+		if i == g.creationClassIndex {
+			buttonColor = color.RGBA{0x30, 0x50, 0x80, 0xFF}
+		}
+		ebitenutil.DrawRect(screen, float64(x), float64(y), float64(width), float64(height), buttonColor)
+		drawRectBorder(screen, x, y, width, height, color.RGBA{0xC0, 0xC0, 0xC0, 0xFF})
+
+		if sprite := g.classSprite(class); sprite != nil {
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Scale(0.75, 0.75)
+			op.GeoM.Translate(float64(x+4), float64(y+3))
+			screen.DrawImage(sprite, op)
+		}
+
+		definition, _ := LookupClass(class)
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(float64(x+36), float64(y+8))
+		text.Draw(screen, definition.Name, g.fontFace, opts)
+	}
+
+	x, y, width, height := g.enterWorldButtonBounds()
+	ebitenutil.DrawRect(screen, float64(x), float64(y), float64(width), float64(height), color.RGBA{0x20, 0x60, 0x20, 0xFF})
+	drawRectBorder(screen, x, y, width, height, color.RGBA{0xC0, 0xC0, 0xC0, 0xFF})
+
+	g.mutex.RLock()
+	requested := g.characterRequested
+	creationError := g.creationError
+	g.mutex.RUnlock()
+
+	label := "Enter World"
+	if requested {
+		label = "Entering..."
+	}
+	opts = &text.DrawOptions{}
+	opts.GeoM.Translate(float64(x+40), float64(y+8))
+	text.Draw(screen, label, g.fontFace, opts)
+
+	if creationError != "" {
+		errorWidth, _ := text.Measure(creationError, g.fontFace, 0)
+		opts = &text.DrawOptions{}
+		opts.GeoM.Translate(float64(g.screenWidth)/2-errorWidth/2, float64(y+height+14))
+		opts.ColorScale.ScaleWithColor(color.RGBA{0xFF, 0x60, 0x60, 0xFF})
+		text.Draw(screen, creationError, g.fontFace, opts)
+	}
+}
+
+// drawRectBorder outlines a rectangle with a 2 pixel border
+func drawRectBorder(screen *ebiten.Image, x, y, width, height int, borderColor color.Color) {
+	ebitenutil.DrawRect(screen, float64(x), float64(y), float64(width), 2, borderColor)
+	ebitenutil.DrawRect(screen, float64(x), float64(y+height-2), float64(width), 2, borderColor)
+	ebitenutil.DrawRect(screen, float64(x), float64(y), 2, float64(height), borderColor)
+	ebitenutil.DrawRect(screen, float64(x+width-2), float64(y), 2, float64(height), borderColor)
+}
+
+// pointInRect reports whether a screen point lies inside a rectangle
+func pointInRect(px, py, x, y, width, height int) bool {
+	return px >= x && px < x+width && py >= y && py < y+height
+}
@@ -0,0 +1,394 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	chatInputHeight    = 18
+	maxChatInputLength = 255
+
+	chatBubbleDuration = 6 * time.Second
+	chatBubbleWidth    = 160 // Widest a bubble line gets before wrapping, in pixels
+	chatBubbleLines    = 3
+	chatBubbleLineStep = 14
+
+	announcementDuration = 8 * time.Second
+)
+
+// chatCommands maps each slash command to the channel it speaks on
+var chatCommands = map[string]types.ChatChannel{
+	"s":       types.ChatSay,
+	"say":     types.ChatSay,
+	"y":       types.ChatYell,
+	"yell":    types.ChatYell,
+	"p":       types.ChatParty,
+	"party":   types.ChatParty,
+	"w":       types.ChatWhisper,
+	"whisper": types.ChatWhisper,
+	"t":       types.ChatWhisper,
+	"tell":    types.ChatWhisper,
+	"g":       types.ChatGlobal,
+	"global":  types.ChatGlobal,
+}
+
+var chatChannelLabels = map[types.ChatChannel]string{
+	types.ChatSay:     "Say",
+	types.ChatYell:    "Yell",
+	types.ChatParty:   "Party",
+	types.ChatWhisper: "Whisper",
+	types.ChatGlobal:  "Global",
+}
+
+// chatBubble is the last thing a nearby player said, shown above their head
+type chatBubble struct {
+	text      string
+	yell      bool
+	createdAt time.Time
+}
+
+func (g *GameClient) processChatMessage(msg types.Message) {
+	var chat types.ChatMessage
+	if err := json.Unmarshal(msg.Data, &chat); err != nil {
+		log.Printf("Error unmarshaling chat message: %v", err)
+		return
+	}
+
+	var line string
+	switch chat.Channel {
+	case types.ChatSay:
+		line = fmt.Sprintf("%s says: %s", chat.SenderName, chat.Text)
+	case types.ChatYell:
+		line = fmt.Sprintf("%s yells: %s", chat.SenderName, chat.Text)
+	case types.ChatParty:
+		line = fmt.Sprintf("[Party] %s: %s", chat.SenderName, chat.Text)
+	case types.ChatWhisper:
+		if chat.SenderID == g.localPlayerID {
+			line = fmt.Sprintf("To %s: %s", chat.Target, chat.Text)
+		} else {
+			line = fmt.Sprintf("%s whispers: %s", chat.SenderName, chat.Text)
+			g.mutex.Lock()
+			g.chatReplyTarget = chat.SenderName
+			g.mutex.Unlock()
+		}
+	case types.ChatGlobal:
+		line = fmt.Sprintf("[Global] %s: %s", chat.SenderName, chat.Text)
+	default:
+		return
+	}
+
+	if chat.Channel == types.ChatSay || chat.Channel == types.ChatYell {
+		g.mutex.Lock()
+		g.chatBubbles[chat.SenderID] = &chatBubble{
+			text:      chat.Text,
+			yell:      chat.Channel == types.ChatYell,
+			createdAt: time.Now(),
+		}
+		g.mutex.Unlock()
+	}
+
+	g.addCombatLogEntry(logChat, line)
+}
+
+// processAnnouncement shows a server-wide announcement across the top of the screen
+func (g *GameClient) processAnnouncement(msg types.Message) {
+	var announcement string
+	if err := json.Unmarshal(msg.Data, &announcement); err != nil {
+		log.Printf("Error unmarshaling announcement: %v", err)
+		return
+	}
+
+	g.mutex.Lock()
+	g.announcement = announcement
+	g.announcementAt = time.Now()
+	g.mutex.Unlock()
+
+	g.addMessage(fmt.Sprintf("[Announcement] %s", announcement))
+}
+
+func (g *GameClient) drawAnnouncement(screen *ebiten.Image) {
+	g.mutex.RLock()
+	announcement := g.announcement
+	elapsed := time.Since(g.announcementAt)
+	g.mutex.RUnlock()
+
+	if announcement == "" || elapsed >= announcementDuration {
+		return
+	}
+
+	textWidth, _ := text.Measure(announcement, g.fontFace, 0)
+	x := (float64(g.screenWidth) - textWidth) / 2
+	y := 50.0
+
+	ebitenutil.DrawRect(screen, x-10, y-4, textWidth+20, 24, color.RGBA{0x00, 0x00, 0x00, 0xC0})
+
+	// Fade out over the last second
+	alpha := min(1, (announcementDuration - elapsed).Seconds())
+	opts := &text.DrawOptions{}
+	opts.GeoM.Translate(x, y)
+	opts.ColorScale.ScaleWithColor(color.RGBA{0xFF, 0xD0, 0x40, 0xFF})
+	opts.ColorScale.ScaleAlpha(float32(alpha))
+	text.Draw(screen, announcement, g.fontFace, opts)
+}
+
+// handleChatInput opens the chat box on Enter and collects typed text until it
+// is sent or cancelled. It returns true while the chat box has the keyboard.
+func (g *GameClient) handleChatInput() bool {
+	if !g.chatActive {
+		// Enter confirms destroying an item while that prompt is up
+		if g.pendingDestroySlot >= 0 {
+			return false
+		}
+
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			g.chatActive = true
+			g.chatInput = g.chatInput[:0]
+			return true
+		}
+		// Slash opens the chat box with a command already started
+		if inpututil.IsKeyJustPressed(ebiten.KeySlash) {
+			g.chatActive = true
+			g.chatInput = append(g.chatInput[:0], '/')
+			return true
+		}
+		return false
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.chatActive = false
+		return true
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.chatActive = false
+		g.sendChat(string(g.chatInput))
+		return true
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(g.chatInput) > 0 {
+		g.chatInput = g.chatInput[:len(g.chatInput)-1]
+	}
+
+	for _, char := range ebiten.AppendInputChars(nil) {
+		if len(g.chatInput) < maxChatInputLength {
+			g.chatInput = append(g.chatInput, char)
+		}
+	}
+	return true
+}
+
+// sendChat sends a line typed in the chat box. A leading slash command picks
+// the channel, and the channel sticks for the lines typed after it.
+func (g *GameClient) sendChat(input string) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return
+	}
+
+	channel := g.chatChannel
+	target := g.chatWhisperTarget
+
+	if strings.HasPrefix(input, "/") {
+		command, rest, _ := strings.Cut(input[1:], " ")
+		command = strings.ToLower(command)
+		rest = strings.TrimSpace(rest)
+
+		if command == "r" || command == "reply" {
+			g.mutex.RLock()
+			replyTarget := g.chatReplyTarget
+			g.mutex.RUnlock()
+			if replyTarget == "" {
+				g.addMessage("Nobody has whispered to you yet")
+				return
+			}
+			channel, target, input = types.ChatWhisper, replyTarget, rest
+		} else if commandChannel, exists := chatCommands[command]; exists {
+			channel, input = commandChannel, rest
+			if channel == types.ChatWhisper {
+				target, input = g.splitWhisperTarget(rest)
+				if target == "" {
+					g.addMessage("Usage: /w <name> <message>")
+					return
+				}
+			}
+		} else if _, isAdmin := LookupAdminCommand(command); isAdmin {
+			// The server checks whether we are allowed to run it
+			g.sendMessage(types.MsgAdminCommand, types.AdminCommand{Command: command, Args: rest})
+			return
+		} else {
+			g.addMessage(fmt.Sprintf("Unknown chat command: /%s", command))
+			return
+		}
+
+		g.chatChannel = channel
+		g.chatWhisperTarget = target
+	}
+
+	if input == "" {
+		return
+	}
+
+	g.sendMessage(types.MsgChat, types.ChatMessage{Channel: channel, Target: target, Text: input})
+}
+
+// splitWhisperTarget separates the recipient from the message in "/w <name> <message>".
+// Names can contain spaces, so the longest name of a known player wins; otherwise
+// the first word is taken as the name.
+func (g *GameClient) splitWhisperTarget(input string) (target, message string) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	lowered := strings.ToLower(input)
+	for _, player := range g.players {
+		name := strings.ToLower(player.Name)
+		if len(name) <= len(target) || !strings.HasPrefix(lowered, name) {
+			continue
+		}
+		if len(lowered) == len(name) || lowered[len(name)] == ' ' {
+			target = player.Name
+		}
+	}
+
+	if target == "" {
+		target, message, _ = strings.Cut(input, " ")
+		return target, strings.TrimSpace(message)
+	}
+	return target, strings.TrimSpace(input[len(target):])
+}
+
+// pruneChatBubbles drops bubbles that have been up long enough, and those of players who left
+func (g *GameClient) pruneChatBubbles() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for playerID, bubble := range g.chatBubbles {
+		if _, exists := g.players[playerID]; !exists || time.Since(bubble.createdAt) >= chatBubbleDuration {
+			delete(g.chatBubbles, playerID)
+		}
+	}
+}
+
+// drawChatBubbles draws each speaker's latest say or yell above their head
+func (g *GameClient) drawChatBubbles(screen *ebiten.Image) {
+	type placedBubble struct {
+		chatBubble
+		x, y float64
+	}
+
+	g.mutex.RLock()
+	bubbles := make([]placedBubble, 0, len(g.chatBubbles))
+	for playerID, bubble := range g.chatBubbles {
+		if player, exists := g.players[playerID]; exists {
+			bubbles = append(bubbles, placedBubble{chatBubble: *bubble, x: player.X - g.cameraX, y: player.Y - g.cameraY})
+		}
+	}
+	g.mutex.RUnlock()
+
+	for _, bubble := range bubbles {
+		lines := g.wrapText(bubble.text, chatBubbleWidth, chatBubbleLines)
+
+		width := 0.0
+		for _, line := range lines {
+			lineWidth, _ := text.Measure(line, g.fontFace, 0)
+			width = max(width, lineWidth)
+		}
+		width += 8
+		height := float64(len(lines)*chatBubbleLineStep + 4)
+
+		// Sit just above the player's name
+		left := bubble.x - width/2
+		top := bubble.y - 30 - height
+
+		borderColor := color.RGBA{0xC0, 0xC0, 0xC0, 0xFF}
+		textColor := color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+		if bubble.yell {
+			borderColor = color.RGBA{0xFF, 0x60, 0x40, 0xFF}
+			textColor = color.RGBA{0xFF, 0x90, 0x70, 0xFF}
+		}
+
+		ebitenutil.DrawRect(screen, left, top, width, height, color.RGBA{0x00, 0x00, 0x00, 0xC0})
+		drawRectBorder(screen, int(left), int(top), int(width), int(height), borderColor)
+
+		for i, line := range lines {
+			opts := &text.DrawOptions{}
+			opts.GeoM.Translate(left+4, top+2+float64(i*chatBubbleLineStep))
+			opts.ColorScale.ScaleWithColor(textColor)
+			text.Draw(screen, line, g.fontFace, opts)
+		}
+	}
+}
+
+// wrapText breaks text into lines no wider than width, ending with "..." when it
+// needs more than maxLines
+func (g *GameClient) wrapText(message string, width float64, maxLines int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(message) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if candidateWidth, _ := text.Measure(candidate, g.fontFace, 0); candidateWidth <= width || line == "" {
+			line = candidate
+			continue
+		}
+		lines = append(lines, line)
+		line = word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] += "..."
+	}
+	return lines
+}
+
+// drawChatInput draws the chat box just above the combat log while it is open
+func (g *GameClient) drawChatInput(screen *ebiten.Image) {
+	if !g.chatActive {
+		return
+	}
+
+	x, y, width, _ := g.combatLogBounds()
+	y -= chatInputHeight + 2
+
+	ebitenutil.DrawRect(screen, float64(x), float64(y), float64(width), chatInputHeight, color.RGBA{0x00, 0x00, 0x00, 0xC0})
+	drawRectBorder(screen, x, y, width, chatInputHeight, color.RGBA{0xAA, 0xAA, 0xFF, 0xFF})
+
+	prompt := chatChannelLabels[g.chatChannel] + ": "
+	if g.chatChannel == types.ChatWhisper {
+		prompt = fmt.Sprintf("To %s: ", g.chatWhisperTarget)
+	}
+	if strings.HasPrefix(string(g.chatInput), "/") {
+		prompt = ""
+	}
+
+	// Keep the end of long messages in view
+	line := prompt + string(g.chatInput) + "_"
+	for len(line) > 0 {
+		if lineWidth, _ := text.Measure(line, g.fontFace, 0); lineWidth <= float64(width-8) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(line)
+		line = line[size:]
+	}
+
+	opts := &text.DrawOptions{}
+	opts.GeoM.Translate(float64(x+4), float64(y+1))
+	text.Draw(screen, line, g.fontFace, opts)
+}
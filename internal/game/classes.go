@@ -0,0 +1,58 @@
+package game
+
+import "github.com/CollinEMac/tarnation/internal/types"
+
+// ClassDefinition describes a playable class's starting attributes and gear
+type ClassDefinition struct {
+	Name          string
+	BaseStats     types.StatModifiers
+	StartingGear  []string            // Item IDs equipped on character creation
+	StatsPerLevel types.StatModifiers // Base attributes gained on each level up
+	Resource      ResourceConfig
+}
+
+// classDefinitions are the classes offered at character creation, keyed by class ID
+var classDefinitions = map[string]ClassDefinition{
+	"warrior": {
+		Name:          "Warrior",
+		BaseStats:     types.StatModifiers{Strength: 10, Agility: 8, Intellect: 3, Stamina: 10, Armor: 50},
+		StartingGear:  []string{"wooden_sword"},
+		StatsPerLevel: types.StatModifiers{Strength: 2, Agility: 1, Stamina: 2, Armor: 5},
+		Resource:      rageResource,
+	},
+	"mage": {
+		Name:          "Mage",
+		BaseStats:     types.StatModifiers{Strength: 3, Agility: 5, Intellect: 14, Stamina: 7, Armor: 10},
+		StartingGear:  []string{"apprentice_wand"},
+		StatsPerLevel: types.StatModifiers{Agility: 1, Intellect: 3, Stamina: 1},
+		Resource:      manaResource,
+	},
+	"priest": {
+		Name:          "Priest",
+		BaseStats:     types.StatModifiers{Strength: 4, Agility: 4, Intellect: 13, Stamina: 9, Armor: 15},
+		StartingGear:  []string{"acolytes_mace"},
+		StatsPerLevel: types.StatModifiers{Strength: 1, Intellect: 2, Stamina: 2},
+		Resource:      manaResource,
+	},
+	"rogue": {
+		Name:          "Rogue",
+		BaseStats:     types.StatModifiers{Strength: 7, Agility: 14, Intellect: 3, Stamina: 8, Armor: 30},
+		StartingGear:  []string{"worn_dagger"},
+		StatsPerLevel: types.StatModifiers{Strength: 1, Agility: 2, Stamina: 1, Armor: 2},
+		Resource:      energyResource,
+	},
+}
+
+// playableClasses lists class IDs in the order the character creation screen shows them
+var playableClasses = []string{"warrior", "mage", "priest", "rogue"}
+
+// PlayableClasses returns the class IDs a new character can choose from
+func PlayableClasses() []string {
+	return playableClasses
+}
+
+// LookupClass returns the definition for a class ID
+func LookupClass(class string) (ClassDefinition, bool) {
+	definition, exists := classDefinitions[class]
+	return definition, exists
+}
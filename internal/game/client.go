@@ -30,6 +30,8 @@ type GameClient struct {
 	room             types.Room
 	localPlayerID    string
 	targetEnemyID    string    // ID of currently targeted enemy
+	targetFriendlyID string    // ID of currently targeted friendly player
+	groundTargetSlot *actionBarSlot // Ground-targeted ability waiting for the reticle to be placed
 	selectedEntityID string    // ID of currently selected entity (for nameplate)
 	selectedEntityType string  // Type of selected entity ("player" or "enemy")
 	lastAttackTime   time.Time // For attack timing
@@ -37,8 +39,15 @@ type GameClient struct {
 	connected        bool
 	lastMoveTime     time.Time
 	moveThrottle     time.Duration
-	messages         []string // For displaying debug info
+	combatLog        []combatLogEntry // System and combat messages, oldest first
+	combatLogFilter  combatLogCategory
+	combatLogScroll  int  // Lines scrolled up from the newest entry
+	showCombatLog    bool // Toggled with the L key
 	shouldClose      bool     // Flag to indicate clean shutdown
+	showCharacterSheet bool   // Toggled with the C key
+	showInventory      bool   // Toggled with the I key
+	inventoryDrag      *inventoryDrag // Item being dragged in the inventory window
+	pendingDestroySlot int    // Bag slot waiting for the player to confirm destroying it, -1 when none
 	
 	cameraX          float64
 	cameraY          float64
@@ -46,26 +55,64 @@ type GameClient struct {
 	screenHeight     int
 	
 	warriorSprite       *ebiten.Image
+	mageSprite          *ebiten.Image
+	priestSprite        *ebiten.Image
+	rogueSprite         *ebiten.Image
 	dirtFloorSprite     *ebiten.Image
 	criticalStrikeSprite *ebiten.Image
 	
 	fontFace            text.Face
+	critFontFace        text.Face
+
+	floatingTexts       []*floatingText
+	projectiles         map[string]*types.Projectile
+	threatTables        map[string]*receivedThreatTable // Enemy ID -> latest threat table from the server
+	groundEffects       map[string]*receivedGroundEffect
+	corpses             map[string]*types.Corpse
+	lootWindow          *types.LootWindow // Contents of the corpse being looted, nil when closed
+	actionBarItems      map[int]string // Action bar slot -> consumable the player placed there
+	party               *types.Party       // Local player's party, nil when not in one
+	partyInvite         *types.PartyInvite // Open invitation waiting for an answer
+	chatActive          bool   // Chat box is open and has the keyboard
+	chatInput           []rune
+	chatChannel         types.ChatChannel // Channel lines without a slash command go to
+	chatWhisperTarget   string // Recipient when chatChannel is whisper
+	chatReplyTarget     string // Last player to whisper us, for /r
+	chatBubbles         map[string]*chatBubble // Player ID -> what they last said nearby
+	announcement        string    // Latest server announcement, shown for a few seconds
+	announcementAt      time.Time
+
+	creationName        []rune // Character creation screen state
+	creationClassIndex  int
+	characterRequested  bool
+	creationError       string // Why the server turned down the last character, shown until the next attempt
 }
 
 func NewGameClient() *GameClient {
 	client := &GameClient{
-		players:      make(map[string]*types.Player),
-		enemies:      make(map[string]*types.Enemy),
-		moveThrottle: 16 * time.Millisecond, // Limit movement updates to ~60/sec to match render loop
-		messages:     make([]string, 0),
-		shouldClose:  false,
-		screenWidth:  800,
-		screenHeight: 600,
-		cameraX:      0,
-		cameraY:      0,
+		players:       make(map[string]*types.Player),
+		enemies:       make(map[string]*types.Enemy),
+		projectiles:   make(map[string]*types.Projectile),
+		threatTables:  make(map[string]*receivedThreatTable),
+		groundEffects: make(map[string]*receivedGroundEffect),
+		corpses:       make(map[string]*types.Corpse),
+		actionBarItems: make(map[int]string),
+		chatBubbles:   make(map[string]*chatBubble),
+		chatChannel:   types.ChatSay,
+		moveThrottle:  16 * time.Millisecond, // Limit movement updates to ~60/sec to match render loop
+		showCombatLog: true,
+		shouldClose:   false,
+		screenWidth:   800,
+		screenHeight:  600,
+		cameraX:       0,
+		cameraY:       0,
+		pendingDestroySlot: -1,
 	}
 	
 	client.loadWarriorSprite()
+	client.loadMageSprite()
+	client.loadPriestSprite()
+	client.loadRogueSprite()
 	client.loadDirtFloorSprite()
 	client.loadCriticalStrikeSprite()
 	
@@ -83,6 +130,47 @@ func (g *GameClient) loadWarriorSprite() {
 	g.warriorSprite = ebiten.NewImageFromImage(img)
 }
 
+func (g *GameClient) loadMageSprite() {
+	img, _, err := image.Decode(bytes.NewReader(assets.MagePNG))
+	if err != nil {
+		log.Printf("Failed to load mage sprite: %v", err)
+		return
+	}
+	g.mageSprite = ebiten.NewImageFromImage(img)
+}
+
+func (g *GameClient) loadPriestSprite() {
+	img, _, err := image.Decode(bytes.NewReader(assets.PriestPNG))
+	if err != nil {
+		log.Printf("Failed to load priest sprite: %v", err)
+		return
+	}
+	g.priestSprite = ebiten.NewImageFromImage(img)
+}
+
+func (g *GameClient) loadRogueSprite() {
+	img, _, err := image.Decode(bytes.NewReader(assets.RoguePNG))
+	if err != nil {
+		log.Printf("Failed to load rogue sprite: %v", err)
+		return
+	}
+	g.rogueSprite = ebiten.NewImageFromImage(img)
+}
+
+// classSprite returns the sprite for a player class, falling back to the warrior
+func (g *GameClient) classSprite(class string) *ebiten.Image {
+	switch {
+	case class == "mage" && g.mageSprite != nil:
+		return g.mageSprite
+	case class == "priest" && g.priestSprite != nil:
+		return g.priestSprite
+	case class == "rogue" && g.rogueSprite != nil:
+		return g.rogueSprite
+	default:
+		return g.warriorSprite
+	}
+}
+
 func (g *GameClient) loadDirtFloorSprite() {
 	img, _, err := image.Decode(bytes.NewReader(assets.DirtFloorPNG))
 	if err != nil {
@@ -111,6 +199,10 @@ func (g *GameClient) loadFont() {
 		Source: source,
 		Size:   12,
 	}
+	g.critFontFace = &text.GoTextFace{
+		Source: source,
+		Size:   18,
+	}
 }
 
 func (g *GameClient) ConnectToServer(url string) error {
@@ -187,6 +279,9 @@ func (g *GameClient) processMessage(msg types.Message) {
 			playerName = player.Name
 			delete(g.players, msg.PlayerID)
 		}
+		if g.targetFriendlyID == msg.PlayerID {
+			g.targetFriendlyID = ""
+		}
 		g.mutex.Unlock()
 
 		// Add message outside the mutex lock to avoid deadlock
@@ -226,7 +321,45 @@ func (g *GameClient) processMessage(msg types.Message) {
 			existingPlayer.MaxHealth = player.MaxHealth
 			existingPlayer.Mana = player.Mana
 			existingPlayer.MaxMana = player.MaxMana
+			existingPlayer.Resource = player.Resource
+			existingPlayer.Level = player.Level
+			existingPlayer.XP = player.XP
+			existingPlayer.ComboPoints = player.ComboPoints
+			existingPlayer.ComboTargetID = player.ComboTargetID
 			existingPlayer.Dead = player.Dead
+			existingPlayer.Released = player.Released
+			existingPlayer.RespawnAt = player.RespawnAt
+			existingPlayer.Cooldowns = player.Cooldowns
+			existingPlayer.Auras = player.Auras
+			existingPlayer.Strength = player.Strength
+			existingPlayer.Agility = player.Agility
+			existingPlayer.Intellect = player.Intellect
+			existingPlayer.Stamina = player.Stamina
+			existingPlayer.Derived = player.Derived
+			existingPlayer.Gold = player.Gold
+			existingPlayer.Bag = player.Bag
+			existingPlayer.Equipment = player.Equipment
+			existingPlayer.Weapon = player.Weapon
+			existingPlayer.PartyID = player.PartyID
+			existingPlayer.Armor = player.Armor
+		}
+		g.mutex.Unlock()
+
+	case types.MsgPlayerTeleport:
+		var teleportData struct {
+			X float64 `json:"x"`
+			Y float64 `json:"y"`
+		}
+
+		if err := json.Unmarshal(msg.Data, &teleportData); err != nil {
+			log.Printf("Error unmarshaling teleport data: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		if player, exists := g.players[msg.PlayerID]; exists {
+			player.X = teleportData.X
+			player.Y = teleportData.Y
 		}
 		g.mutex.Unlock()
 
@@ -252,11 +385,9 @@ func (g *GameClient) processMessage(msg types.Message) {
 		}
 
 		if err := json.Unmarshal(msg.Data, &deathData); err == nil && deathData.Dead {
-			// Enemy is dead - remove from game
+			// Enemy is dead - remove from game. The death itself is reported by a combat event.
 			g.mutex.Lock()
-			var enemyName string
-			if enemy, exists := g.enemies[deathData.ID]; exists {
-				enemyName = enemy.Name
+			if _, exists := g.enemies[deathData.ID]; exists {
 				delete(g.enemies, deathData.ID)
 
 				// Clear target if this was our target
@@ -265,11 +396,6 @@ func (g *GameClient) processMessage(msg types.Message) {
 				}
 			}
 			g.mutex.Unlock()
-
-			// Add message after releasing the lock to avoid deadlock
-			if enemyName != "" {
-				g.addMessage(fmt.Sprintf("Enemy %s has been defeated!", enemyName))
-			}
 		} else {
 			// Parse as full enemy update
 			var enemy types.Enemy
@@ -284,10 +410,30 @@ func (g *GameClient) processMessage(msg types.Message) {
 				existingEnemy.Y = enemy.Y
 				existingEnemy.Health = enemy.Health
 				existingEnemy.MaxHealth = enemy.MaxHealth
+				existingEnemy.Auras = enemy.Auras
+				existingEnemy.Phase = enemy.Phase
+				existingEnemy.Enraged = enemy.Enraged
+				existingEnemy.AIState = enemy.AIState
 			}
 			g.mutex.Unlock()
 		}
 
+	case types.MsgEnemyPositions:
+		var positions []types.EnemyPosition
+		if err := json.Unmarshal(msg.Data, &positions); err != nil {
+			log.Printf("Error unmarshaling enemy positions: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		for _, position := range positions {
+			if enemy, exists := g.enemies[position.ID]; exists {
+				enemy.X = position.X
+				enemy.Y = position.Y
+			}
+		}
+		g.mutex.Unlock()
+
 	case types.MsgRoomData:
 		var room types.Room
 		if err := json.Unmarshal(msg.Data, &room); err != nil {
@@ -299,8 +445,69 @@ func (g *GameClient) processMessage(msg types.Message) {
 		g.room = room
 		g.mutex.Unlock()
 
+	case types.MsgZoneChange:
+		g.processZoneChange(msg)
+
+	case types.MsgCombatEvent:
+		var event types.CombatEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			log.Printf("Error unmarshaling combat event: %v", err)
+			return
+		}
+
+		g.addFloatingText(event)
+		g.logCombatEvent(event)
+
+	case types.MsgProjectileSpawn, types.MsgProjectileRemove:
+		g.processProjectileMessage(msg)
+
+	case types.MsgThreatUpdate:
+		g.processThreatUpdate(msg)
+
+	case types.MsgGroundEffect:
+		g.processGroundEffect(msg)
+
+	case types.MsgCorpseSpawn, types.MsgCorpseRemove, types.MsgLootWindow, types.MsgLootRule:
+		g.processLootMessage(msg)
+
+	case types.MsgXPGain, types.MsgLevelUp:
+		g.processExperienceMessage(msg)
+
+	case types.MsgPartyInvite, types.MsgPartyUpdate, types.MsgNotice:
+		g.processPartyMessage(msg)
+
+	case types.MsgChat:
+		g.processChatMessage(msg)
+
+	case types.MsgAnnouncement:
+		g.processAnnouncement(msg)
+
+	case types.MsgEnemyYell:
+		var yell types.EnemyYell
+		if err := json.Unmarshal(msg.Data, &yell); err != nil {
+			log.Printf("Error unmarshaling enemy yell: %v", err)
+			return
+		}
+		g.addMessage(fmt.Sprintf("%s yells: %s", yell.Name, yell.Text))
+
 	case types.MsgError:
-		g.addMessage(fmt.Sprintf("Server error: %s", string(msg.Data)))
+		var errorText string
+		if err := json.Unmarshal(msg.Data, &errorText); err != nil {
+			errorText = string(msg.Data)
+		}
+
+		// Before entering the world an error means the character was turned down, such as for a taken name
+		g.mutex.Lock()
+		rejected := g.localPlayerID == "" && g.characterRequested
+		if rejected {
+			g.characterRequested = false
+			g.creationError = errorText
+		}
+		g.mutex.Unlock()
+
+		if !rejected {
+			g.addMessage(fmt.Sprintf("Server error: %s", errorText))
+		}
 
 	default:
 		log.Printf("Unknown message type: %s", msg.Type)
@@ -335,9 +542,29 @@ func (g *GameClient) Update() error {
 		return nil
 	}
 
-	g.handleInput()
+	g.mutex.RLock()
+	inWorld := g.localPlayerID != ""
+	g.mutex.RUnlock()
+
+	if !inWorld {
+		g.handleCharacterCreationInput()
+		return nil
+	}
+
+	// The chat box takes the keyboard while it is open
+	if !g.handleChatInput() {
+		g.handleCombatLogInput()
+		g.handleInput()
+	}
 	
 	g.updateCamera()
+
+	g.pruneFloatingTexts()
+	g.pruneChatBubbles()
+	g.pruneThreatTables()
+	g.pruneGroundEffects()
+	g.closeLootWindowIfOutOfRange()
+	g.updateProjectiles()
 	
 	return nil
 }
@@ -371,8 +598,9 @@ func (g *GameClient) handleInput() {
 		return
 	}
 
-	// Don't process inputs if player is dead
+	// Dead players can only interact with the death screen
 	if localPlayer.Dead {
+		g.handleDeathScreenInput(localPlayer)
 		return
 	}
 
@@ -397,7 +625,10 @@ func (g *GameClient) handleInput() {
 		moved = true
 	}
 
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	// Clicks place or cancel a pending ground-targeted ability, take loot or move items, instead of selecting
+	clickConsumed := g.handleGroundTargetingInput() || g.handlePartyInput() || g.handleLootWindowInput() || g.handleInventoryInput() || g.handleActionBarClick()
+
+	if !clickConsumed && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !g.isCursorOverCombatLog() {
 		mouseX, mouseY := ebiten.CursorPosition()
 		worldX := float64(mouseX) + g.cameraX
 		worldY := float64(mouseY) + g.cameraY
@@ -414,7 +645,8 @@ func (g *GameClient) handleInput() {
 		}
 	}
 
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+	// Right-clicking targets: enemies become the hostile target, players the friendly target
+	if !clickConsumed && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
 		mouseX, mouseY := ebiten.CursorPosition()
 		worldX := float64(mouseX) + g.cameraX
 		worldY := float64(mouseY) + g.cameraY
@@ -423,25 +655,55 @@ func (g *GameClient) handleInput() {
 			g.targetEnemyID = enemyID
 			g.selectedEntityID = enemyID
 			g.selectedEntityType = "enemy"
+		} else if playerID := g.getPlayerAt(worldX, worldY); playerID != "" {
+			g.targetFriendlyID = playerID
+			g.selectedEntityID = playerID
+			g.selectedEntityType = "player"
+		} else if corpseID := g.getCorpseAt(worldX, worldY); corpseID != "" {
+			g.openCorpse(corpseID)
 		} else {
 			g.targetEnemyID = ""
+			g.targetFriendlyID = ""
 		}
 	}
 
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.mutex.RLock()
+		selectedPlayer, isPlayer := g.players[g.selectedEntityID]
+		canResurrect := g.selectedEntityType == "player" && isPlayer && selectedPlayer.Dead && !selectedPlayer.Released
+		g.mutex.RUnlock()
+
+		if canResurrect {
+			g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+				"action": "resurrect",
+				"target": g.selectedEntityID,
+			})
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		g.showCharacterSheet = !g.showCharacterSheet
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		g.toggleInventory()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyO) {
+		g.cycleLootRule()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) && g.selectedEntityType == "player" {
+		g.invitePlayer(g.selectedEntityID)
+	}
+
 	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
 		g.sendMessage(types.MsgPlayerAction, map[string]string{
 			"action": "basic_attack",
 		})
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.Key1) {
-		if g.targetEnemyID != "" && g.isLocalPlayerWarrior() {
-			g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
-				"action": "critical_strike",
-				"target": g.targetEnemyID,
-			})
-		}
-	}
+	g.handleActionBarInput()
 
 	if g.targetEnemyID != "" {
 		g.mutex.RLock()
@@ -455,10 +717,9 @@ func (g *GameClient) handleInput() {
 			dy := targetEnemy.Y - localPlayer.Y
 			distance := math.Sqrt(dx*dx + dy*dy)
 
-			weaponRange := 30.0        // Default range
+			weaponRange := WeaponReach(localPlayer.Weapon)
 			weaponDelay := time.Second // Default weapon speed
 			if localPlayer.Weapon != nil {
-				weaponRange = float64(localPlayer.Weapon.Range * 25) // Scale range
 				weaponDelay = localPlayer.Weapon.Delay
 			}
 
@@ -546,8 +807,20 @@ func (g *GameClient) updateCamera() {
 func (g *GameClient) Draw(screen *ebiten.Image) {
 	screen.Fill(color.RGBA{0x20, 0x20, 0x20, 0xff})
 
+	g.mutex.RLock()
+	onCreationScreen := g.connected && g.localPlayerID == ""
+	g.mutex.RUnlock()
+
+	if onCreationScreen {
+		g.drawCharacterCreation(screen)
+		return
+	}
+
 	g.drawFloor(screen)
 	g.drawWalls(screen)
+	g.drawPortals(screen)
+	g.drawGroundEffects(screen)
+	g.drawCorpses(screen)
 
 	g.mutex.RLock()
 
@@ -581,6 +854,11 @@ func (g *GameClient) Draw(screen *ebiten.Image) {
 		g.drawPlayer(screen, player)
 	}
 
+	g.drawProjectiles(screen)
+	g.drawGroundReticle(screen)
+	g.drawChatBubbles(screen)
+	g.drawFloatingTexts(screen)
+
 	g.drawUI(screen)
 
 	// Check if local player is dead and show death screen
@@ -617,7 +895,7 @@ func (g *GameClient) drawPlayer(screen *ebiten.Image, player *types.Player) {
 	if screenX >= -20 && screenX <= float64(g.screenWidth)+20 &&
 	   screenY >= -20 && screenY <= float64(g.screenHeight)+20 {
 		
-		if g.warriorSprite != nil {
+		if sprite := g.classSprite(player.Class); sprite != nil {
 			op := &ebiten.DrawImageOptions{}
 			op.GeoM.Translate(screenX-16, screenY-16)
 			
@@ -626,7 +904,7 @@ func (g *GameClient) drawPlayer(screen *ebiten.Image, player *types.Player) {
 				op.ColorScale.Scale(0.3, 0.3, 0.3, 0.7) // Dark grey and semi-transparent
 			}
 					
-			screen.DrawImage(g.warriorSprite, op)
+			screen.DrawImage(sprite, op)
 		} else {
 			playerColor := color.RGBA{0x80, 0x80, 0xff, 0xff} // Blue for other players
 			if player.ID == g.localPlayerID {
@@ -670,18 +948,39 @@ func (g *GameClient) drawEnemy(screen *ebiten.Image, enemy *types.Enemy) {
 	   screenY >= -20 && screenY <= float64(g.screenHeight)+20 {
 		
 		enemyColor := color.RGBA{0xff, 0xff, 0xff, 0xff}
+		if enemy.EnemyType == "archer" {
+			enemyColor = color.RGBA{0xa0, 0xe0, 0x80, 0xff}
+		}
+
+		// Bosses are drawn larger, and turn red once enraged
+		size := 20.0
+		if enemy.Boss {
+			size = 32.0
+			enemyColor = color.RGBA{0x90, 0x60, 0xc0, 0xff}
+			if enemy.Enraged {
+				enemyColor = color.RGBA{0xe0, 0x30, 0x30, 0xff}
+			}
+		}
 
-		ebitenutil.DrawRect(screen, screenX-10, screenY-10, 20, 20, enemyColor)
+		ebitenutil.DrawRect(screen, screenX-size/2, screenY-size/2, size, size, enemyColor)
 
 		opts := &text.DrawOptions{}
-		opts.GeoM.Translate(screenX-20, screenY-25)
-		text.Draw(screen, enemy.Name, g.fontFace, opts)
+		displayName := enemy.Name
+		switch enemy.AIState {
+		case types.AIFlee:
+			displayName += " (Fleeing)"
+		case types.AIEvade:
+			displayName += " (Evading)"
+		}
+
+		opts.GeoM.Translate(screenX-20, screenY-size/2-15)
+		text.Draw(screen, displayName, g.fontFace, opts)
 
 		barWidth := 30.0
 		barHeight := 4.0
 		healthPercent := float64(enemy.Health) / float64(enemy.MaxHealth)
 
-		ebitenutil.DrawRect(screen, screenX-barWidth/2, screenY+15, barWidth*healthPercent, barHeight, color.RGBA{0x00, 0xff, 0x00, 0xff})
+		ebitenutil.DrawRect(screen, screenX-barWidth/2, screenY+size/2+5, barWidth*healthPercent, barHeight, color.RGBA{0x00, 0xff, 0x00, 0xff})
 	}
 }
 
@@ -693,8 +992,82 @@ func (g *GameClient) drawUI(screen *ebiten.Image) {
 	ebitenutil.DebugPrint(screen, fmt.Sprintf("Status: %s | Players: %d", status, len(g.players)))
 
 	g.drawNameplate(screen)
+	g.drawThreatMeter(screen)
 	g.drawPlayerResources(screen)
 	g.drawActionBar(screen)
+	g.drawCombatLog(screen)
+	g.drawChatInput(screen)
+	g.drawPartyFrames(screen)
+	g.drawPartyInvite(screen)
+	g.drawAnnouncement(screen)
+	g.drawLootWindow(screen)
+	g.drawInventory(screen)
+
+	if g.showCharacterSheet {
+		g.drawCharacterSheet(screen)
+	}
+}
+
+func (g *GameClient) drawCharacterSheet(screen *ebiten.Image) {
+	g.mutex.RLock()
+	localPlayer, exists := g.players[g.localPlayerID]
+	g.mutex.RUnlock()
+
+	if !exists || localPlayer == nil {
+		return
+	}
+
+	panelX := 20
+	panelY := 40
+	panelWidth := 200
+	panelHeight := 240
+
+	ebitenutil.DrawRect(screen, float64(panelX), float64(panelY), float64(panelWidth), float64(panelHeight), color.RGBA{0x00, 0x00, 0x00, 0xC0})
+	ebitenutil.DrawRect(screen, float64(panelX), float64(panelY), float64(panelWidth), 2, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	ebitenutil.DrawRect(screen, float64(panelX), float64(panelY+panelHeight-2), float64(panelWidth), 2, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	ebitenutil.DrawRect(screen, float64(panelX), float64(panelY), 2, float64(panelHeight), color.RGBA{0xff, 0xff, 0xff, 0xff})
+	ebitenutil.DrawRect(screen, float64(panelX+panelWidth-2), float64(panelY), 2, float64(panelHeight), color.RGBA{0xff, 0xff, 0xff, 0xff})
+
+	bonus := addStats(EquipmentStats(localPlayer.Equipment), TotalStatModifiers(localPlayer.Auras))
+	lines := []string{
+		fmt.Sprintf("%s (%s)", localPlayer.Name, localPlayer.Class),
+		formatLevelLine(localPlayer),
+		"",
+		formatStatLine("Strength", localPlayer.Strength, bonus.Strength),
+		formatStatLine("Agility", localPlayer.Agility, bonus.Agility),
+		formatStatLine("Intellect", localPlayer.Intellect, bonus.Intellect),
+		formatStatLine("Stamina", localPlayer.Stamina, bonus.Stamina),
+		"",
+		fmt.Sprintf("Max Health: %d", localPlayer.Derived.MaxHealth),
+		fmt.Sprintf("Attack Power: %d", localPlayer.Derived.AttackPower),
+		fmt.Sprintf("Crit Chance: %.1f%%", localPlayer.Derived.CritChance*100),
+		fmt.Sprintf("Spell Power: %d", localPlayer.Derived.SpellPower),
+		"",
+		fmt.Sprintf("Gold: %d", localPlayer.Gold),
+	}
+
+	for i, line := range lines {
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(float64(panelX+8), float64(panelY+8+i*15))
+		text.Draw(screen, line, g.fontFace, opts)
+	}
+}
+
+// formatLevelLine shows a character's level and experience, e.g. "Level 3 (120/450 XP)"
+func formatLevelLine(player *types.Player) string {
+	needed := XPToNextLevel(player.Level)
+	if needed == 0 {
+		return fmt.Sprintf("Level %d", player.Level)
+	}
+	return fmt.Sprintf("Level %d (%d/%d XP)", player.Level, player.XP, needed)
+}
+
+// formatStatLine shows an attribute with any equipment and aura bonus, e.g. "Strength: 15 (+5)"
+func formatStatLine(name string, base, bonus int) string {
+	if bonus == 0 {
+		return fmt.Sprintf("%s: %d", name, base)
+	}
+	return fmt.Sprintf("%s: %d (%+d)", name, base+bonus, bonus)
 }
 
 func (g *GameClient) drawPlayerResources(screen *ebiten.Image) {
@@ -730,23 +1103,14 @@ func (g *GameClient) drawPlayerResources(screen *ebiten.Image) {
 	
 	opts := &text.DrawOptions{}
 	opts.GeoM.Translate(barX, barY-15)
-	text.Draw(screen, fmt.Sprintf("Health: %d/%d", localPlayer.Health, localPlayer.MaxHealth), g.fontFace, opts)
+	text.Draw(screen, fmt.Sprintf("Level %d  Health: %d/%d", localPlayer.Level, localPlayer.Health, localPlayer.MaxHealth), g.fontFace, opts)
+
+	g.drawAuraIcons(screen, localPlayer.Auras, barX, barY-50)
 	
 	resourceY := barY + barHeight + barSpacing
 	resourcePercent := float64(localPlayer.Mana) / float64(localPlayer.MaxMana)
 	
-	var resourceLabel string
-	var resourceBgColor, resourceFgColor color.RGBA
-	
-	if localPlayer.Class == "warrior" {
-		resourceLabel = "Rage"
-		resourceBgColor = color.RGBA{0x40, 0x20, 0x00, 0xFF} // Dark orange
-		resourceFgColor = color.RGBA{0xFF, 0x80, 0x00, 0xFF} // Orange
-	} else {
-		resourceLabel = "Mana"
-		resourceBgColor = color.RGBA{0x00, 0x20, 0x40, 0xFF} // Dark blue
-		resourceFgColor = color.RGBA{0x00, 0x80, 0xFF, 0xFF} // Blue
-	}
+	resourceLabel, resourceBgColor, resourceFgColor := resourceStyle(localPlayer.Resource)
 	
 	ebitenutil.DrawRect(screen, barX, resourceY, barWidth, barHeight, resourceBgColor)
 	ebitenutil.DrawRect(screen, barX, resourceY, barWidth*resourcePercent, barHeight, resourceFgColor)
@@ -758,17 +1122,26 @@ func (g *GameClient) drawPlayerResources(screen *ebiten.Image) {
 	resourceOpts := &text.DrawOptions{}
 	resourceOpts.GeoM.Translate(barX, resourceY-15)
 	text.Draw(screen, fmt.Sprintf("%s: %d/%d", resourceLabel, localPlayer.Mana, localPlayer.MaxMana), g.fontFace, resourceOpts)
+
+	drawXPBar(screen, localPlayer, barX, resourceY+barHeight+3, barWidth)
+}
+
+// resourceStyle returns the label and bar colors for a class resource
+func resourceStyle(resource types.ResourceType) (label string, bg, fg color.RGBA) {
+	switch resource {
+	case types.ResourceRage:
+		return "Rage", color.RGBA{0x40, 0x20, 0x00, 0xFF}, color.RGBA{0xFF, 0x80, 0x00, 0xFF} // Orange
+	case types.ResourceEnergy:
+		return "Energy", color.RGBA{0x40, 0x40, 0x00, 0xFF}, color.RGBA{0xFF, 0xE0, 0x00, 0xFF} // Yellow
+	default:
+		return "Mana", color.RGBA{0x00, 0x20, 0x40, 0xFF}, color.RGBA{0x00, 0x80, 0xFF, 0xFF} // Blue
+	}
 }
 
 func (g *GameClient) drawActionBar(screen *ebiten.Image) {
-	slotCount := 8
-	slotSize := 40
-	slotSpacing := 4
-	barWidth := slotCount*slotSize + (slotCount-1)*slotSpacing + 16 // +16 for padding
-	barHeight := slotSize + 16 // +16 for padding
-	
-	barX := (g.screenWidth - barWidth) / 2
-	barY := g.screenHeight - barHeight - 10 // 10 pixels from bottom
+	slotCount := len(actionBarKeys)
+	slotSize := actionBarSlotSize
+	barX, barY, barWidth, barHeight := g.actionBarBounds()
 	
 	barBgColor := color.RGBA{0x20, 0x20, 0x20, 0xE0}
 	barBorderColor := color.RGBA{0x60, 0x60, 0x60, 0xFF}
@@ -782,10 +1155,20 @@ func (g *GameClient) drawActionBar(screen *ebiten.Image) {
 	
 	slotBgColor := color.RGBA{0x40, 0x40, 0x40, 0xFF}
 	slotBorderColor := color.RGBA{0x80, 0x80, 0x80, 0xFF}
+
+	slots := g.localActionBar()
+
+	g.mutex.RLock()
+	var cooldowns map[string]int64
+	var bag []types.ItemStack
+	if localPlayer, exists := g.players[g.localPlayerID]; exists {
+		cooldowns = localPlayer.Cooldowns
+		bag = localPlayer.Bag
+	}
+	g.mutex.RUnlock()
 	
 	for i := 0; i < slotCount; i++ {
-		slotX := barX + 8 + i*(slotSize+slotSpacing) // 8 for padding
-		slotY := barY + 8 // 8 for padding
+		slotX, slotY := g.actionBarSlotBounds(i)
 		
 		ebitenutil.DrawRect(screen, float64(slotX), float64(slotY), float64(slotSize), float64(slotSize), slotBgColor)
 		
@@ -794,11 +1177,23 @@ func (g *GameClient) drawActionBar(screen *ebiten.Image) {
 		ebitenutil.DrawRect(screen, float64(slotX), float64(slotY), 1, float64(slotSize), slotBorderColor)
 		ebitenutil.DrawRect(screen, float64(slotX+slotSize-1), float64(slotY), 1, float64(slotSize), slotBorderColor)
 		
-		if i == 0 && g.criticalStrikeSprite != nil && g.isLocalPlayerWarrior() {
+		var slot actionBarSlot
+		if i < len(slots) {
+			slot = slots[i]
+		}
+
+		if slot.itemID != "" {
+			g.drawItemIcon(screen, slotX+4, slotY+4, types.ItemStack{ItemID: slot.itemID, Count: CountInBag(bag, slot.itemID)})
+
+			// Grey out consumables the player has run out of
+			if CountInBag(bag, slot.itemID) == 0 {
+				ebitenutil.DrawRect(screen, float64(slotX), float64(slotY), float64(slotSize), float64(slotSize), color.RGBA{0x00, 0x00, 0x00, 0xA0})
+			}
+		} else if icon := g.abilityIcon(slot.action); icon != nil {
 			op := &ebiten.DrawImageOptions{}
 			
 			iconSize := float64(slotSize - 4)
-			spriteWidth, spriteHeight := g.criticalStrikeSprite.Bounds().Dx(), g.criticalStrikeSprite.Bounds().Dy()
+			spriteWidth, spriteHeight := icon.Bounds().Dx(), icon.Bounds().Dy()
 			scaleX := iconSize / float64(spriteWidth)
 			scaleY := iconSize / float64(spriteHeight)
 			scale := min(scaleX, scaleY)
@@ -811,25 +1206,37 @@ func (g *GameClient) drawActionBar(screen *ebiten.Image) {
 			offsetY := (float64(slotSize) - scaledHeight) / 2
 			
 			op.GeoM.Translate(float64(slotX)+offsetX, float64(slotY)+offsetY)
-			screen.DrawImage(g.criticalStrikeSprite, op)
+			screen.DrawImage(icon, op)
+		} else if slot.label != "" {
+			opts := &text.DrawOptions{}
+			opts.GeoM.Translate(float64(slotX+3), float64(slotY+slotSize/2-7))
+			text.Draw(screen, slot.label, g.fontFace, opts)
 		} else {
 			opts := &text.DrawOptions{}
 			opts.GeoM.Translate(float64(slotX+slotSize/2-3), float64(slotY+slotSize/2-4))
 			text.Draw(screen, fmt.Sprintf("%d", i+1), g.fontFace, opts)
 		}
+
+		// Darken abilities that are still on cooldown and show the seconds left
+		if readyAt := cooldowns[slot.cooldownKey()]; slot.action != "" && readyAt > time.Now().UnixMilli() {
+			remaining := (readyAt - time.Now().UnixMilli() + 999) / 1000
+			ebitenutil.DrawRect(screen, float64(slotX), float64(slotY), float64(slotSize), float64(slotSize), color.RGBA{0x00, 0x00, 0x00, 0xA0})
+
+			opts := &text.DrawOptions{}
+			opts.GeoM.Translate(float64(slotX+slotSize/2-6), float64(slotY+slotSize/2-6))
+			text.Draw(screen, fmt.Sprintf("%d", remaining), g.fontFace, opts)
+		}
 	}
 }
 
-func (g *GameClient) isLocalPlayerWarrior() bool {
-	g.mutex.RLock()
-	defer g.mutex.RUnlock()
-	
-	localPlayer, exists := g.players[g.localPlayerID]
-	if !exists {
-		return false
+// abilityIcon returns the icon sprite for an ability, or nil if it has none
+func (g *GameClient) abilityIcon(action string) *ebiten.Image {
+	switch action {
+	case "critical_strike":
+		return g.criticalStrikeSprite
+	default:
+		return nil
 	}
-	
-	return localPlayer.Class == "warrior"
 }
 
 func (g *GameClient) drawNameplate(screen *ebiten.Image) {
@@ -843,27 +1250,43 @@ func (g *GameClient) drawNameplate(screen *ebiten.Image) {
 	}
 
 	var name string
-	var health, maxHealth, mana, maxMana int
-	var exists bool
+	var level, health, maxHealth, mana, maxMana, comboPoints int
+	var auras []*types.Aura
+	var exists, pullingAggro bool
+	resource := types.ResourceMana
 
 	if selectedType == "player" {
 		if player, ok := g.players[selectedID]; ok {
 			name = player.Name
+			level = player.Level
 			health = player.Health
 			maxHealth = player.MaxHealth
 			mana = player.Mana
 			maxMana = player.MaxMana
+			resource = player.Resource
+			auras = player.Auras
 			exists = true
 		}
 	} else if selectedType == "enemy" {
 		if enemy, ok := g.enemies[selectedID]; ok {
 			name = enemy.Name
+			level = enemy.Level
 			health = enemy.Health
 			maxHealth = enemy.MaxHealth
 			mana = enemy.Mana
 			maxMana = enemy.MaxMana
+			auras = enemy.Auras
 			exists = true
 		}
+
+		// Show the local player's combo points on the enemy they were built on
+		if localPlayer, ok := g.players[g.localPlayerID]; ok && localPlayer.ComboTargetID == selectedID {
+			comboPoints = localPlayer.ComboPoints
+		}
+
+		if table, ok := g.threatTableFor(selectedID); ok {
+			pullingAggro = g.isAboutToPullAggro(table)
+		}
 	}
 
 	if !exists {
@@ -880,20 +1303,35 @@ func (g *GameClient) drawNameplate(screen *ebiten.Image) {
 	nameplateHeight := 80
 
 	ebitenutil.DrawRect(screen, float64(nameplateX), float64(nameplateY), float64(nameplateWidth), float64(nameplateHeight), color.RGBA{0x00, 0x00, 0x00, 0x80})
-	ebitenutil.DrawRect(screen, float64(nameplateX), float64(nameplateY), float64(nameplateWidth), 2, color.RGBA{0xff, 0xff, 0xff, 0xff})
-	ebitenutil.DrawRect(screen, float64(nameplateX), float64(nameplateY+nameplateHeight-2), float64(nameplateWidth), 2, color.RGBA{0xff, 0xff, 0xff, 0xff})
-	ebitenutil.DrawRect(screen, float64(nameplateX), float64(nameplateY), 2, float64(nameplateHeight), color.RGBA{0xff, 0xff, 0xff, 0xff})
-	ebitenutil.DrawRect(screen, float64(nameplateX+nameplateWidth-2), float64(nameplateY), 2, float64(nameplateHeight), color.RGBA{0xff, 0xff, 0xff, 0xff})
+	if pullingAggro {
+		drawAggroGlow(screen, nameplateX, nameplateY, nameplateWidth, nameplateHeight)
+	}
+
+	// Frame friendly players in green and enemies in red
+	frameColor := color.RGBA{0x40, 0xC0, 0x40, 0xff}
+	if selectedType == "enemy" {
+		frameColor = color.RGBA{0xC0, 0x40, 0x40, 0xff}
+	}
+	ebitenutil.DrawRect(screen, float64(nameplateX), float64(nameplateY), float64(nameplateWidth), 2, frameColor)
+	ebitenutil.DrawRect(screen, float64(nameplateX), float64(nameplateY+nameplateHeight-2), float64(nameplateWidth), 2, frameColor)
+	ebitenutil.DrawRect(screen, float64(nameplateX), float64(nameplateY), 2, float64(nameplateHeight), frameColor)
+	ebitenutil.DrawRect(screen, float64(nameplateX+nameplateWidth-2), float64(nameplateY), 2, float64(nameplateHeight), frameColor)
 
 	opts := &text.DrawOptions{}
 	opts.GeoM.Translate(float64(nameplateX+5), float64(nameplateY+5))
-	text.Draw(screen, fmt.Sprintf("Name: %s", name), g.fontFace, opts)
+	text.Draw(screen, fmt.Sprintf("Name: %s (Lv %d)", name, level), g.fontFace, opts)
 	opts = &text.DrawOptions{}
 	opts.GeoM.Translate(float64(nameplateX+5), float64(nameplateY+20))
 	text.Draw(screen, fmt.Sprintf("Health: %d/%d", health, maxHealth), g.fontFace, opts)
 	opts = &text.DrawOptions{}
 	opts.GeoM.Translate(float64(nameplateX+5), float64(nameplateY+35))
-	text.Draw(screen, fmt.Sprintf("Mana: %d/%d", mana, maxMana), g.fontFace, opts)
+	resourceLabel, resourceBgColor, resourceFgColor := resourceStyle(resource)
+	text.Draw(screen, fmt.Sprintf("%s: %d/%d", resourceLabel, mana, maxMana), g.fontFace, opts)
+
+	for i := 0; i < comboPoints; i++ {
+		pipX := float64(nameplateX+nameplateWidth-12) - float64(i)*10
+		ebitenutil.DrawRect(screen, pipX, float64(nameplateY+8), 7, 7, color.RGBA{0xFF, 0xD0, 0x00, 0xff})
+	}
 
 	barWidth := float64(nameplateWidth - 10)
 	barHeight := 8.0
@@ -904,8 +1342,51 @@ func (g *GameClient) drawNameplate(screen *ebiten.Image) {
 
 	manaPercent := float64(mana) / float64(maxMana)
 	if maxMana > 0 {
-		ebitenutil.DrawRect(screen, float64(nameplateX+5), float64(nameplateY+62), barWidth, barHeight, color.RGBA{0x00, 0x00, 0x80, 0xff})
-		ebitenutil.DrawRect(screen, float64(nameplateX+5), float64(nameplateY+62), barWidth*manaPercent, barHeight, color.RGBA{0x00, 0x80, 0xff, 0xff})
+		ebitenutil.DrawRect(screen, float64(nameplateX+5), float64(nameplateY+62), barWidth, barHeight, resourceBgColor)
+		ebitenutil.DrawRect(screen, float64(nameplateX+5), float64(nameplateY+62), barWidth*manaPercent, barHeight, resourceFgColor)
+	}
+
+	g.drawAuraIcons(screen, auras, float64(nameplateX), float64(nameplateY+nameplateHeight+4))
+}
+
+// drawAuraIcons draws a row of aura icons with their stacks and remaining time
+func (g *GameClient) drawAuraIcons(screen *ebiten.Image, auras []*types.Aura, x, y float64) {
+	iconSize := 18.0
+	iconSpacing := 6.0
+	nowMillis := time.Now().UnixMilli()
+
+	for i, aura := range auras {
+		iconX := x + float64(i)*(iconSize+iconSpacing)
+
+		borderColor := color.RGBA{0x00, 0xC0, 0x00, 0xFF} // Green for buffs
+		if aura.Harmful {
+			borderColor = color.RGBA{0xC0, 0x00, 0x00, 0xFF} // Red for debuffs
+		}
+
+		ebitenutil.DrawRect(screen, iconX, y, iconSize, iconSize, borderColor)
+		ebitenutil.DrawRect(screen, iconX+1, y+1, iconSize-2, iconSize-2, color.RGBA{0x20, 0x20, 0x20, 0xFF})
+
+		if aura.Name != "" {
+			opts := &text.DrawOptions{}
+			opts.GeoM.Translate(iconX+5, y+2)
+			text.Draw(screen, aura.Name[:1], g.fontFace, opts)
+		}
+
+		if aura.Stacks > 1 {
+			opts := &text.DrawOptions{}
+			opts.GeoM.Translate(iconX+iconSize-6, y+iconSize-10)
+			text.Draw(screen, fmt.Sprintf("%d", aura.Stacks), g.fontFace, opts)
+		}
+
+		remaining := (aura.ExpiresAt - nowMillis) / 1000
+		timerText := fmt.Sprintf("%ds", max(remaining, 0))
+		if remaining >= 60 {
+			timerText = fmt.Sprintf("%dm", remaining/60)
+		}
+
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(iconX, y+iconSize)
+		text.Draw(screen, timerText, g.fontFace, opts)
 	}
 }
 
@@ -1048,15 +1529,9 @@ func (g *GameClient) getPlayerAt(x, y float64) string {
 	return ""
 }
 
+// addMessage records a system message in the combat log
 func (g *GameClient) addMessage(msg string) {
-	g.mutex.Lock()
-	defer g.mutex.Unlock()
-
-	g.messages = append([]string{fmt.Sprintf("[%s] %s", time.Now().Format("15:04:05"), msg)}, g.messages...)
-
-	if len(g.messages) > 10 {
-		g.messages = g.messages[:10]
-	}
+	g.addCombatLogEntry(logSystem, msg)
 }
 
 func (g *GameClient) drawDeathScreen(screen *ebiten.Image) {
@@ -1072,4 +1547,52 @@ func (g *GameClient) drawDeathScreen(screen *ebiten.Image) {
 	textY := g.screenHeight / 2 - 20
 	
 	ebitenutil.DebugPrintAt(screen, deathText, textX, textY)
+
+	g.mutex.RLock()
+	localPlayer, exists := g.players[g.localPlayerID]
+	g.mutex.RUnlock()
+
+	if !exists {
+		return
+	}
+
+	if localPlayer.Released {
+		remaining := max(0, (localPlayer.RespawnAt-time.Now().UnixMilli()+999)/1000)
+		respawnText := fmt.Sprintf("Respawning at the graveyard in %d...", remaining)
+		ebitenutil.DebugPrintAt(screen, respawnText, (g.screenWidth-len(respawnText)*6)/2, textY+30)
+		return
+	}
+
+	buttonX, buttonY, buttonWidth, buttonHeight := g.releaseButtonBounds()
+	ebitenutil.DrawRect(screen, float64(buttonX), float64(buttonY), float64(buttonWidth), float64(buttonHeight), color.RGBA{0x60, 0x20, 0x20, 0xFF})
+	ebitenutil.DrawRect(screen, float64(buttonX), float64(buttonY), float64(buttonWidth), 2, color.RGBA{0xC0, 0xC0, 0xC0, 0xFF})
+	ebitenutil.DrawRect(screen, float64(buttonX), float64(buttonY+buttonHeight-2), float64(buttonWidth), 2, color.RGBA{0xC0, 0xC0, 0xC0, 0xFF})
+	ebitenutil.DrawRect(screen, float64(buttonX), float64(buttonY), 2, float64(buttonHeight), color.RGBA{0xC0, 0xC0, 0xC0, 0xFF})
+	ebitenutil.DrawRect(screen, float64(buttonX+buttonWidth-2), float64(buttonY), 2, float64(buttonHeight), color.RGBA{0xC0, 0xC0, 0xC0, 0xFF})
+	ebitenutil.DebugPrintAt(screen, "Release", buttonX+(buttonWidth-7*6)/2, buttonY+(buttonHeight-16)/2)
+
+	hintText := "or wait for another player to resurrect you"
+	ebitenutil.DebugPrintAt(screen, hintText, (g.screenWidth-len(hintText)*6)/2, buttonY+buttonHeight+10)
+}
+
+// releaseButtonBounds returns the screen rectangle of the death screen's Release button
+func (g *GameClient) releaseButtonBounds() (x, y, width, height int) {
+	width = 120
+	height = 30
+	return (g.screenWidth - width) / 2, g.screenHeight/2 + 10, width, height
+}
+
+// handleDeathScreenInput sends a release request when the Release button is clicked
+func (g *GameClient) handleDeathScreenInput(localPlayer *types.Player) {
+	if localPlayer.Released || !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	buttonX, buttonY, buttonWidth, buttonHeight := g.releaseButtonBounds()
+	if mouseX >= buttonX && mouseX < buttonX+buttonWidth && mouseY >= buttonY && mouseY < buttonY+buttonHeight {
+		g.sendMessage(types.MsgPlayerAction, map[string]string{
+			"action": "release",
+		})
+	}
 }
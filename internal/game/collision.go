@@ -50,7 +50,95 @@ func CreateDungeonRoom() types.Room {
 		{X: 0, Y: 0, Width: 20, Height: 900 },
 		// Right wall
 		{X: 1180, Y: 0, Width: 20, Height: 900 },
+		// Pillar to take cover behind from ranged attacks
+		{X: 640, Y: 100, Width: 40, Height: 120},
 	}
 	
-	return types.Room{Walls: walls}
+	return types.Room{
+		Zone:      DungeonZone,
+		Name:      "The Dungeon",
+		Walls:     walls,
+		Entry:     types.Point{X: 400, Y: 300},
+		Graveyard: types.Point{X: 100, Y: 100},
+		Portals: []types.Portal{
+			// Doorway in the east wall down to the crypt
+			{X: 1130, Y: 400, Width: 40, Height: 80, ToZone: CryptZone, Label: "To the Crypt"},
+		},
+	}
+}
+
+// CreateCryptRoom creates the crypt below the dungeon, a smaller room broken up by tombs
+func CreateCryptRoom() types.Room {
+	walls := []types.Wall{
+		// Outer walls
+		{X: 0, Y: 0, Width: 1000, Height: 20},
+		{X: 0, Y: 780, Width: 1000, Height: 20},
+		{X: 0, Y: 0, Width: 20, Height: 800},
+		{X: 980, Y: 0, Width: 20, Height: 800},
+		// Rows of tombs
+		{X: 300, Y: 150, Width: 120, Height: 50},
+		{X: 300, Y: 600, Width: 120, Height: 50},
+		{X: 600, Y: 150, Width: 120, Height: 50},
+		{X: 600, Y: 600, Width: 120, Height: 50},
+	}
+
+	return types.Room{
+		Zone:      CryptZone,
+		Name:      "The Crypt",
+		Walls:     walls,
+		Entry:     types.Point{X: 120, Y: 400},
+		Graveyard: types.Point{X: 120, Y: 120},
+		Portals: []types.Portal{
+			// Stairs in the west wall back up to the dungeon
+			{X: 30, Y: 360, Width: 40, Height: 80, ToZone: DungeonZone, Label: "To the Dungeon"},
+			// Passage in the east wall down into the depths
+			{X: 930, Y: 360, Width: 40, Height: 80, ToZone: DepthsZone, Label: "To the Depths"},
+		},
+	}
+}
+
+// SegmentHitsWall reports whether the straight line between two points passes
+// through any wall, for projectiles and line of sight
+func SegmentHitsWall(x1, y1, x2, y2 float64, walls []types.Wall) bool {
+	for _, wall := range walls {
+		if segmentIntersectsRect(x1, y1, x2, y2, wall) {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentIntersectsRect clips the segment against the wall's bounds (Liang-Barsky)
+func segmentIntersectsRect(x1, y1, x2, y2 float64, wall types.Wall) bool {
+	dx := x2 - x1
+	dy := y2 - y1
+	enter, exit := 0.0, 1.0
+
+	edges := []struct{ p, q float64 }{
+		{-dx, x1 - wall.X},
+		{dx, wall.X + wall.Width - x1},
+		{-dy, y1 - wall.Y},
+		{dy, wall.Y + wall.Height - y1},
+	}
+
+	for _, edge := range edges {
+		if edge.p == 0 {
+			if edge.q < 0 {
+				return false // Parallel to this edge and outside it
+			}
+			continue
+		}
+
+		t := edge.q / edge.p
+		if edge.p < 0 {
+			enter = max(enter, t)
+		} else {
+			exit = min(exit, t)
+		}
+		if enter > exit {
+			return false
+		}
+	}
+
+	return true
 }
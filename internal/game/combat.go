@@ -0,0 +1,167 @@
+package game
+
+import (
+	"math"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	critMultiplier     = 2
+	healCritMultiplier = 1.5
+	armorConstant      = 400.0 // Armor at which mitigation reaches 50%
+	maxArmorReduce     = 0.75  // Armor can never mitigate more than 75% of a hit
+	defaultMinSwing    = 1
+)
+
+// RNG is the source of randomness for combat rolls. *rand.Rand satisfies it,
+// so tests can pass a seeded generator to get reproducible results.
+type RNG interface {
+	Float64() float64
+	Intn(n int) int
+}
+
+// AttackRoll is the result of rolling a single attack against a defender
+type AttackRoll struct {
+	HitType   types.HitType
+	Damage    int // Damage dealt after mitigation
+	Mitigated int // Damage absorbed by armor
+}
+
+// Landed reports whether the attack connected with its target
+func (r AttackRoll) Landed() bool {
+	return r.HitType == types.HitNormal || r.HitType == types.HitCrit
+}
+
+// RollWeaponDamage rolls a swing of the weapon, including the attack power bonus.
+// Weapons without a damage range always deal their flat Damage.
+func RollWeaponDamage(rng RNG, weapon *types.Weapon, attackPower int) int {
+	if weapon == nil {
+		return defaultMinSwing
+	}
+
+	damage := weapon.Damage
+	if weapon.MaxDamage > weapon.MinDamage {
+		damage = weapon.MinDamage + rng.Intn(weapon.MaxDamage-weapon.MinDamage+1)
+	} else if weapon.MinDamage > 0 {
+		damage = weapon.MinDamage
+	}
+
+	return max(defaultMinSwing, damage+AttackPowerBonus(attackPower, weapon.Delay))
+}
+
+// RollMeleeAttack resolves an auto attack on a single-roll table:
+// miss, dodge, parry, crit, then a normal hit
+func RollMeleeAttack(rng RNG, damage int, attacker, defender types.DerivedStats) AttackRoll {
+	roll := rng.Float64()
+	damage = levelScaledDamage(damage, attacker, defender)
+
+	missCeiling := attacker.MissChance + levelMissChance(attacker, defender)
+	dodgeCeiling := missCeiling + defender.DodgeChance
+	parryCeiling := dodgeCeiling + defender.ParryChance
+	critCeiling := parryCeiling + attacker.CritChance
+
+	switch {
+	case roll < missCeiling:
+		return AttackRoll{HitType: types.HitMiss}
+	case roll < dodgeCeiling:
+		return AttackRoll{HitType: types.HitDodge}
+	case roll < parryCeiling:
+		return AttackRoll{HitType: types.HitParry}
+	case roll < critCeiling:
+		return mitigatedRoll(types.HitCrit, damage*critMultiplier, defender.Armor)
+	default:
+		return mitigatedRoll(types.HitNormal, damage, defender.Armor)
+	}
+}
+
+// RollSpecialAttack resolves an ability that always crits when it lands, such as
+// Critical Strike. It can still be missed, dodged or parried.
+func RollSpecialAttack(rng RNG, damage int, attacker, defender types.DerivedStats) AttackRoll {
+	roll := rng.Float64()
+	damage = levelScaledDamage(damage, attacker, defender)
+	missChance := attacker.MissChance + levelMissChance(attacker, defender)
+
+	switch {
+	case roll < missChance:
+		return AttackRoll{HitType: types.HitMiss}
+	case roll < missChance+defender.DodgeChance:
+		return AttackRoll{HitType: types.HitDodge}
+	case roll < missChance+defender.DodgeChance+defender.ParryChance:
+		return AttackRoll{HitType: types.HitParry}
+	default:
+		return mitigatedRoll(types.HitCrit, damage, defender.Armor)
+	}
+}
+
+// RollRangedAttack resolves a bow shot, which can miss, be dodged or crit but
+// cannot be parried
+func RollRangedAttack(rng RNG, damage int, attacker, defender types.DerivedStats) AttackRoll {
+	roll := rng.Float64()
+	damage = levelScaledDamage(damage, attacker, defender)
+	missChance := attacker.MissChance + levelMissChance(attacker, defender)
+
+	switch {
+	case roll < missChance:
+		return AttackRoll{HitType: types.HitMiss}
+	case roll < missChance+defender.DodgeChance:
+		return AttackRoll{HitType: types.HitDodge}
+	case roll < missChance+defender.DodgeChance+attacker.CritChance:
+		return mitigatedRoll(types.HitCrit, damage*critMultiplier, defender.Armor)
+	default:
+		return mitigatedRoll(types.HitNormal, damage, defender.Armor)
+	}
+}
+
+// RollSpellDamage rolls a spell's base damage range plus its share of spell power
+func RollSpellDamage(rng RNG, minDamage, maxDamage, spellPower int, coefficient float64) int {
+	damage := minDamage
+	if maxDamage > minDamage {
+		damage += rng.Intn(maxDamage - minDamage + 1)
+	}
+	return max(1, damage+int(math.Round(float64(spellPower)*coefficient)))
+}
+
+// RollSpellAttack resolves a spell, which can miss or crit but cannot be
+// dodged or parried and ignores armor
+func RollSpellAttack(rng RNG, damage int, attacker, defender types.DerivedStats) AttackRoll {
+	roll := rng.Float64()
+	damage = levelScaledDamage(damage, attacker, defender)
+	missChance := attacker.MissChance + levelMissChance(attacker, defender)
+
+	switch {
+	case roll < missChance:
+		return AttackRoll{HitType: types.HitMiss}
+	case roll < missChance+attacker.CritChance:
+		return AttackRoll{HitType: types.HitCrit, Damage: damage * critMultiplier}
+	default:
+		return AttackRoll{HitType: types.HitNormal, Damage: damage}
+	}
+}
+
+// RollHeal resolves a direct heal, which always lands and can crit for 150%
+func RollHeal(rng RNG, amount int, healer types.DerivedStats) AttackRoll {
+	if rng.Float64() < healer.CritChance {
+		return AttackRoll{HitType: types.HitCrit, Damage: int(math.Round(float64(amount) * healCritMultiplier))}
+	}
+	return AttackRoll{HitType: types.HitNormal, Damage: amount}
+}
+
+// ArmorReduction returns the fraction of physical damage armor absorbs
+func ArmorReduction(armor int) float64 {
+	if armor <= 0 {
+		return 0
+	}
+	return math.Min(maxArmorReduce, float64(armor)/(float64(armor)+armorConstant))
+}
+
+func mitigatedRoll(hitType types.HitType, damage, armor int) AttackRoll {
+	mitigated := int(math.Round(float64(damage) * ArmorReduction(armor)))
+	dealt := max(1, damage-mitigated)
+
+	return AttackRoll{
+		HitType:   hitType,
+		Damage:    dealt,
+		Mitigated: damage - dealt,
+	}
+}
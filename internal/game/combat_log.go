@@ -0,0 +1,289 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// combatLogCategory groups combat log entries so the pane can filter them
+type combatLogCategory int
+
+const (
+	logAll combatLogCategory = iota // Filter only, never assigned to an entry
+	logDamageDone
+	logDamageTaken
+	logHeal
+	logDeath
+	logThreat
+	logSystem
+	logChat
+	logOther // Damage between other players and enemies
+)
+
+const (
+	maxCombatLogEntries   = 200
+	combatLogVisibleLines = 10
+	combatLogLineHeight   = 14
+	combatLogWidth        = 320
+	combatLogTabHeight    = 18
+)
+
+// combatLogFilters are the tabs shown at the top of the combat log pane
+var combatLogFilters = []struct {
+	category combatLogCategory
+	label    string
+}{
+	{logAll, "All"},
+	{logDamageDone, "Done"},
+	{logDamageTaken, "Taken"},
+	{logHeal, "Heals"},
+	{logDeath, "Deaths"},
+	{logThreat, "Threat"},
+	{logChat, "Chat"},
+}
+
+type combatLogEntry struct {
+	timestamp time.Time
+	category  combatLogCategory
+	text      string
+}
+
+// addCombatLogEntry appends to the combat log, dropping the oldest entries past the limit
+func (g *GameClient) addCombatLogEntry(category combatLogCategory, msg string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.combatLog = append(g.combatLog, combatLogEntry{
+		timestamp: time.Now(),
+		category:  category,
+		text:      msg,
+	})
+
+	if len(g.combatLog) > maxCombatLogEntries {
+		g.combatLog = g.combatLog[len(g.combatLog)-maxCombatLogEntries:]
+	}
+}
+
+// logCombatEvent records a combat event in the combat log
+func (g *GameClient) logCombatEvent(event types.CombatEvent) {
+	if description := g.describeCombatEvent(event); description != "" {
+		g.addCombatLogEntry(g.combatEventCategory(event), description)
+	}
+}
+
+// combatEventCategory decides which combat log filter an event belongs to
+func (g *GameClient) combatEventCategory(event types.CombatEvent) combatLogCategory {
+	g.mutex.RLock()
+	localPlayerID := g.localPlayerID
+	g.mutex.RUnlock()
+
+	switch event.Kind {
+	case types.CombatHeal, types.CombatResurrect:
+		return logHeal
+	case types.CombatDeath:
+		return logDeath
+	case types.CombatThreat:
+		return logThreat
+	}
+
+	switch localPlayerID {
+	case event.SourceID:
+		return logDamageDone
+	case event.TargetID:
+		return logDamageTaken
+	default:
+		return logOther
+	}
+}
+
+// describeCombatEvent turns a combat event into readable text
+func (g *GameClient) describeCombatEvent(event types.CombatEvent) string {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	sourceName := g.entityName(event.SourceID)
+	sourcePossessive := sourceName + "'s"
+	targetName := g.entityName(event.TargetID)
+
+	if event.SourceID == g.localPlayerID {
+		sourceName = "You"
+		sourcePossessive = "Your"
+	}
+	if event.TargetID == g.localPlayerID {
+		targetName = "you"
+	}
+
+	abilitySuffix := ""
+	if event.Ability != "" && event.Ability != "attack" {
+		abilitySuffix = fmt.Sprintf(" (%s)", event.Ability)
+	}
+
+	switch event.Kind {
+	case types.CombatHeal:
+		return fmt.Sprintf("%s healed %s for %d%s", sourceName, targetName, event.Amount, abilitySuffix)
+	case types.CombatDeath:
+		if event.SourceID == "" {
+			return fmt.Sprintf("%s died", targetName)
+		}
+		return fmt.Sprintf("%s killed %s", sourceName, targetName)
+	case types.CombatThreat:
+		return fmt.Sprintf("%s is now attacking %s", sourceName, targetName)
+	case types.CombatResurrect:
+		return fmt.Sprintf("%s resurrected %s", sourceName, targetName)
+	}
+
+	switch event.HitType {
+	case types.HitMiss:
+		return fmt.Sprintf("%s missed %s%s", sourceName, targetName, abilitySuffix)
+	case types.HitDodge:
+		return fmt.Sprintf("%s attack was dodged by %s%s", sourcePossessive, targetName, abilitySuffix)
+	case types.HitParry:
+		return fmt.Sprintf("%s attack was parried by %s%s", sourcePossessive, targetName, abilitySuffix)
+	case types.HitCrit:
+		return fmt.Sprintf("%s critically hit %s for %d%s", sourceName, targetName, event.Amount, abilitySuffix)
+	default:
+		return fmt.Sprintf("%s hit %s for %d%s", sourceName, targetName, event.Amount, abilitySuffix)
+	}
+}
+
+// entityName returns the display name of a player or enemy. Callers must hold the mutex.
+func (g *GameClient) entityName(id string) string {
+	if player, exists := g.players[id]; exists {
+		return player.Name
+	}
+	if enemy, exists := g.enemies[id]; exists {
+		return enemy.Name
+	}
+	return "Unknown"
+}
+
+// combatLogBounds returns the screen rectangle of the combat log pane
+func (g *GameClient) combatLogBounds() (x, y, width, height int) {
+	height = combatLogTabHeight + combatLogVisibleLines*combatLogLineHeight + 8
+	return 10, g.screenHeight - 120 - height, combatLogWidth, height
+}
+
+// isCursorOverCombatLog reports whether the mouse is over the visible combat log pane
+func (g *GameClient) isCursorOverCombatLog() bool {
+	if !g.showCombatLog {
+		return false
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	x, y, width, height := g.combatLogBounds()
+	return mouseX >= x && mouseX < x+width && mouseY >= y && mouseY < y+height
+}
+
+// handleCombatLogInput toggles, scrolls and filters the combat log pane
+func (g *GameClient) handleCombatLogInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		g.showCombatLog = !g.showCombatLog
+	}
+
+	if !g.isCursorOverCombatLog() {
+		return
+	}
+
+	if _, wheelY := ebiten.Wheel(); wheelY != 0 {
+		g.mutex.Lock()
+		maxScroll := max(0, len(g.filteredCombatLog())-combatLogVisibleLines)
+		g.combatLogScroll = min(maxScroll, max(0, g.combatLogScroll+int(wheelY)))
+		g.mutex.Unlock()
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mouseX, mouseY := ebiten.CursorPosition()
+		x, y, width, _ := g.combatLogBounds()
+		if mouseY < y+combatLogTabHeight {
+			tabWidth := width / len(combatLogFilters)
+			if tab := (mouseX - x) / tabWidth; tab >= 0 && tab < len(combatLogFilters) {
+				g.mutex.Lock()
+				g.combatLogFilter = combatLogFilters[tab].category
+				g.combatLogScroll = 0
+				g.mutex.Unlock()
+			}
+		}
+	}
+}
+
+// filteredCombatLog returns the entries matching the current filter. Callers must hold the mutex.
+func (g *GameClient) filteredCombatLog() []combatLogEntry {
+	if g.combatLogFilter == logAll {
+		return g.combatLog
+	}
+
+	var entries []combatLogEntry
+	for _, entry := range g.combatLog {
+		if entry.category == g.combatLogFilter {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func (g *GameClient) drawCombatLog(screen *ebiten.Image) {
+	if !g.showCombatLog {
+		return
+	}
+
+	g.mutex.RLock()
+	entries := g.filteredCombatLog()
+	filter := g.combatLogFilter
+	scroll := g.combatLogScroll
+	g.mutex.RUnlock()
+
+	x, y, width, height := g.combatLogBounds()
+
+	ebitenutil.DrawRect(screen, float64(x), float64(y), float64(width), float64(height), color.RGBA{0x00, 0x00, 0x00, 0xA0})
+
+	tabWidth := width / len(combatLogFilters)
+	for i, tab := range combatLogFilters {
+		tabX := x + i*tabWidth
+		tabColor := color.RGBA{0x30, 0x30, 0x30, 0xE0}
+		if tab.category == filter {
+			tabColor = color.RGBA{0x60, 0x60, 0x60, 0xE0}
+		}
+		ebitenutil.DrawRect(screen, float64(tabX+1), float64(y+1), float64(tabWidth-2), float64(combatLogTabHeight-2), tabColor)
+
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(float64(tabX+4), float64(y+2))
+		text.Draw(screen, tab.label, g.fontFace, opts)
+	}
+
+	end := max(0, len(entries)-scroll)
+	start := max(0, end-combatLogVisibleLines)
+	for i, entry := range entries[start:end] {
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(float64(x+4), float64(y+combatLogTabHeight+4+i*combatLogLineHeight))
+		opts.ColorScale.ScaleWithColor(combatLogColor(entry.category))
+		text.Draw(screen, fmt.Sprintf("[%s] %s", entry.timestamp.Format("15:04:05"), entry.text), g.fontFace, opts)
+	}
+}
+
+func combatLogColor(category combatLogCategory) color.RGBA {
+	switch category {
+	case logDamageDone:
+		return color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	case logDamageTaken:
+		return color.RGBA{0xFF, 0x60, 0x60, 0xFF}
+	case logHeal:
+		return color.RGBA{0x60, 0xFF, 0x60, 0xFF}
+	case logDeath:
+		return color.RGBA{0xFF, 0xA0, 0x40, 0xFF}
+	case logThreat:
+		return color.RGBA{0xFF, 0xE0, 0x60, 0xFF}
+	case logSystem:
+		return color.RGBA{0x80, 0xC0, 0xFF, 0xFF}
+	case logChat:
+		return color.RGBA{0xAA, 0xAA, 0xFF, 0xFF}
+	default:
+		return color.RGBA{0xB0, 0xB0, 0xB0, 0xFF}
+	}
+}
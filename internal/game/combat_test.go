@@ -0,0 +1,137 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// fixedRNG returns a fixed sequence of rolls so tests can land on each band of a roll table
+type fixedRNG struct {
+	floats []float64
+	ints   []int
+}
+
+func (r *fixedRNG) Float64() float64 {
+	value := r.floats[0]
+	r.floats = r.floats[1:]
+	return value
+}
+
+func (r *fixedRNG) Intn(n int) int {
+	value := r.ints[0] % n
+	r.ints = r.ints[1:]
+	return value
+}
+
+type attackRoller func(rng RNG, damage int, attacker, defender types.DerivedStats) AttackRoll
+
+func TestAttackRollTables(t *testing.T) {
+	// Bands for the melee table: miss below 0.05, dodge below 0.10, parry below 0.15 and crit below 0.25
+	attacker := types.DerivedStats{MissChance: 0.05, CritChance: 0.1}
+	defender := types.DerivedStats{DodgeChance: 0.05, ParryChance: 0.05}
+	armored := types.DerivedStats{DodgeChance: 0.05, ParryChance: 0.05, Armor: 400} // Absorbs half of every hit
+
+	levelled := func(stats types.DerivedStats, level int) types.DerivedStats {
+		stats.Level = level
+		return stats
+	}
+
+	tests := []struct {
+		name          string
+		roll          attackRoller
+		value         float64
+		attacker      types.DerivedStats
+		defender      types.DerivedStats
+		wantHit       types.HitType
+		wantDamage    int
+		wantMitigated int
+	}{
+		{"melee miss", RollMeleeAttack, 0.01, attacker, defender, types.HitMiss, 0, 0},
+		{"melee dodge", RollMeleeAttack, 0.07, attacker, defender, types.HitDodge, 0, 0},
+		{"melee parry", RollMeleeAttack, 0.12, attacker, defender, types.HitParry, 0, 0},
+		{"melee crit", RollMeleeAttack, 0.20, attacker, defender, types.HitCrit, 200, 0},
+		{"melee normal", RollMeleeAttack, 0.50, attacker, defender, types.HitNormal, 100, 0},
+		{"melee normal against armor", RollMeleeAttack, 0.50, attacker, armored, types.HitNormal, 50, 50},
+		{"melee crit against armor", RollMeleeAttack, 0.20, attacker, armored, types.HitCrit, 100, 100},
+
+		{"special miss", RollSpecialAttack, 0.01, attacker, defender, types.HitMiss, 0, 0},
+		{"special dodge", RollSpecialAttack, 0.07, attacker, defender, types.HitDodge, 0, 0},
+		{"special parry", RollSpecialAttack, 0.12, attacker, defender, types.HitParry, 0, 0},
+		{"special always crits when it lands", RollSpecialAttack, 0.50, attacker, defender, types.HitCrit, 100, 0},
+
+		{"ranged miss", RollRangedAttack, 0.01, attacker, defender, types.HitMiss, 0, 0},
+		{"ranged dodge", RollRangedAttack, 0.07, attacker, defender, types.HitDodge, 0, 0},
+		{"ranged cannot be parried", RollRangedAttack, 0.12, attacker, defender, types.HitCrit, 200, 0},
+		{"ranged normal", RollRangedAttack, 0.50, attacker, defender, types.HitNormal, 100, 0},
+
+		{"spell miss", RollSpellAttack, 0.01, attacker, defender, types.HitMiss, 0, 0},
+		{"spell cannot be dodged", RollSpellAttack, 0.07, attacker, defender, types.HitCrit, 200, 0},
+		{"spell normal ignores armor", RollSpellAttack, 0.50, attacker, armored, types.HitNormal, 100, 0},
+
+		// Each level the defender has over the attacker adds 2% to miss and takes 5% off damage
+		{"melee miss against higher level", RollMeleeAttack, 0.08, levelled(attacker, 1), levelled(defender, 3), types.HitMiss, 0, 0},
+		{"melee dodge past level miss", RollMeleeAttack, 0.10, levelled(attacker, 1), levelled(defender, 3), types.HitDodge, 0, 0},
+		{"melee normal against higher level", RollMeleeAttack, 0.50, levelled(attacker, 1), levelled(defender, 3), types.HitNormal, 90, 0},
+		{"melee normal against lower level", RollMeleeAttack, 0.50, levelled(attacker, 5), levelled(defender, 1), types.HitNormal, 120, 0},
+		{"melee no extra miss against lower level", RollMeleeAttack, 0.06, levelled(attacker, 5), levelled(defender, 1), types.HitDodge, 0, 0},
+		{"level damage bonus is capped", RollMeleeAttack, 0.50, levelled(attacker, 30), levelled(defender, 1), types.HitNormal, 150, 0},
+		{"level damage penalty is capped", RollMeleeAttack, 0.99, levelled(attacker, 1), levelled(defender, 30), types.HitNormal, 50, 0},
+		{"level ignored without a defender level", RollMeleeAttack, 0.50, levelled(attacker, 1), defender, types.HitNormal, 100, 0},
+		{"special miss against higher level", RollSpecialAttack, 0.08, levelled(attacker, 1), levelled(defender, 3), types.HitMiss, 0, 0},
+		{"special crit against higher level", RollSpecialAttack, 0.50, levelled(attacker, 1), levelled(defender, 3), types.HitCrit, 90, 0},
+		{"ranged miss against higher level", RollRangedAttack, 0.08, levelled(attacker, 1), levelled(defender, 3), types.HitMiss, 0, 0},
+		{"ranged crit against lower level", RollRangedAttack, 0.12, levelled(attacker, 5), levelled(defender, 1), types.HitCrit, 240, 0},
+		{"spell miss against higher level", RollSpellAttack, 0.08, levelled(attacker, 1), levelled(defender, 3), types.HitMiss, 0, 0},
+		{"spell normal against higher level", RollSpellAttack, 0.50, levelled(attacker, 1), levelled(defender, 3), types.HitNormal, 90, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := &fixedRNG{floats: []float64{tt.value}}
+			got := tt.roll(rng, 100, tt.attacker, tt.defender)
+
+			if got.HitType != tt.wantHit {
+				t.Fatalf("hit type = %q, want %q", got.HitType, tt.wantHit)
+			}
+			if got.Damage != tt.wantDamage {
+				t.Errorf("damage = %d, want %d", got.Damage, tt.wantDamage)
+			}
+			if got.Mitigated != tt.wantMitigated {
+				t.Errorf("mitigated = %d, want %d", got.Mitigated, tt.wantMitigated)
+			}
+			if got.Landed() != (tt.wantDamage > 0) {
+				t.Errorf("landed = %v for a %q", got.Landed(), got.HitType)
+			}
+		})
+	}
+}
+
+func TestRollWeaponDamage(t *testing.T) {
+	weapon := &types.Weapon{Damage: 10, MinDamage: 8, MaxDamage: 12}
+
+	// Intn(5) picks 0-4 above the minimum
+	if got := RollWeaponDamage(&fixedRNG{ints: []int{0}}, weapon, 0); got != 8 {
+		t.Errorf("lowest roll = %d, want 8", got)
+	}
+	if got := RollWeaponDamage(&fixedRNG{ints: []int{4}}, weapon, 0); got != 12 {
+		t.Errorf("highest roll = %d, want 12", got)
+	}
+	if got := RollWeaponDamage(&fixedRNG{}, &types.Weapon{Damage: 10}, 0); got != 10 {
+		t.Errorf("flat weapon = %d, want 10", got)
+	}
+	if got := RollWeaponDamage(&fixedRNG{}, nil, 0); got != defaultMinSwing {
+		t.Errorf("unarmed = %d, want %d", got, defaultMinSwing)
+	}
+}
+
+func TestRollHeal(t *testing.T) {
+	healer := types.DerivedStats{CritChance: 0.1}
+
+	if got := RollHeal(&fixedRNG{floats: []float64{0.05}}, 100, healer); got.HitType != types.HitCrit || got.Damage != 150 {
+		t.Errorf("crit heal = %+v, want a 150 crit", got)
+	}
+	if got := RollHeal(&fixedRNG{floats: []float64{0.5}}, 100, healer); got.HitType != types.HitNormal || got.Damage != 100 {
+		t.Errorf("normal heal = %+v, want a 100 hit", got)
+	}
+}
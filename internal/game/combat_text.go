@@ -0,0 +1,141 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	floatingTextDuration = 1500 * time.Millisecond
+	floatingTextRise     = 40.0 // Pixels a number rises over its lifetime
+)
+
+// floatingText is a damage or heal number rising above the entity it affected
+type floatingText struct {
+	label     string
+	color     color.RGBA
+	crit      bool
+	x, y      float64 // World position of the entity when the event happened
+	offsetX   float64 // Spreads simultaneous numbers apart
+	createdAt time.Time
+}
+
+// addFloatingText queues a floating number for a combat event if its target is known
+func (g *GameClient) addFloatingText(event types.CombatEvent) {
+	if event.Kind != types.CombatDamage && event.Kind != types.CombatHeal && event.Kind != types.CombatResurrect {
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	var x, y float64
+	if player, exists := g.players[event.TargetID]; exists {
+		x, y = player.X, player.Y
+	} else if enemy, exists := g.enemies[event.TargetID]; exists {
+		x, y = enemy.X, enemy.Y
+	} else {
+		return
+	}
+
+	g.floatingTexts = append(g.floatingTexts, &floatingText{
+		label:     floatingTextLabel(event),
+		color:     g.floatingTextColor(event),
+		crit:      event.HitType == types.HitCrit,
+		x:         x,
+		y:         y,
+		offsetX:   float64(len(g.floatingTexts)%3-1) * 12,
+		createdAt: time.Now(),
+	})
+}
+
+// floatingTextLabel formats the text shown for a combat event
+func floatingTextLabel(event types.CombatEvent) string {
+	if event.Kind == types.CombatResurrect {
+		return "Resurrected"
+	}
+
+	switch event.HitType {
+	case types.HitMiss:
+		return "Miss"
+	case types.HitDodge:
+		return "Dodge"
+	case types.HitParry:
+		return "Parry"
+	}
+
+	label := fmt.Sprintf("%d", event.Amount)
+	if event.Kind == types.CombatHeal {
+		label = "+" + label
+	}
+	if event.HitType == types.HitCrit {
+		label += "!"
+	}
+	return label
+}
+
+// floatingTextColor picks a color by event type and who was involved. Callers must hold the mutex.
+func (g *GameClient) floatingTextColor(event types.CombatEvent) color.RGBA {
+	switch {
+	case event.Kind == types.CombatHeal || event.Kind == types.CombatResurrect:
+		return color.RGBA{0x40, 0xFF, 0x40, 0xFF} // Green for heals
+	case event.HitType == types.HitMiss || event.HitType == types.HitDodge || event.HitType == types.HitParry:
+		return color.RGBA{0xA0, 0xA0, 0xC0, 0xFF} // Grey for avoided attacks
+	case event.TargetID == g.localPlayerID:
+		return color.RGBA{0xFF, 0x40, 0x40, 0xFF} // Red for damage taken
+	case event.HitType == types.HitCrit:
+		return color.RGBA{0xFF, 0xE0, 0x00, 0xFF} // Yellow for crits
+	case event.SourceID == g.localPlayerID:
+		return color.RGBA{0xFF, 0xFF, 0xFF, 0xFF} // White for your damage
+	default:
+		return color.RGBA{0xB0, 0xB0, 0xB0, 0xFF} // Light grey for everyone else's damage
+	}
+}
+
+// pruneFloatingTexts drops numbers that have finished fading out
+func (g *GameClient) pruneFloatingTexts() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	remaining := g.floatingTexts[:0]
+	for _, ft := range g.floatingTexts {
+		if time.Since(ft.createdAt) < floatingTextDuration {
+			remaining = append(remaining, ft)
+		}
+	}
+	g.floatingTexts = remaining
+}
+
+func (g *GameClient) drawFloatingTexts(screen *ebiten.Image) {
+	g.mutex.RLock()
+	texts := make([]floatingText, 0, len(g.floatingTexts))
+	for _, ft := range g.floatingTexts {
+		texts = append(texts, *ft)
+	}
+	cameraX := g.cameraX
+	cameraY := g.cameraY
+	g.mutex.RUnlock()
+
+	for _, ft := range texts {
+		progress := float64(time.Since(ft.createdAt)) / float64(floatingTextDuration)
+		if progress >= 1 {
+			continue
+		}
+
+		face := g.fontFace
+		if ft.crit && g.critFontFace != nil {
+			face = g.critFontFace
+		}
+
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(ft.x-cameraX+ft.offsetX-8, ft.y-cameraY-35-floatingTextRise*progress)
+		opts.ColorScale.ScaleWithColor(ft.color)
+		opts.ColorScale.ScaleAlpha(float32(1 - progress))
+		text.Draw(screen, ft.label, face, opts)
+	}
+}
@@ -0,0 +1,296 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// DungeonCellSize is the size in pixels of one cell of a generated dungeon's grid.
+// Walls, rooms and corridors all line up on it.
+const DungeonCellSize = 40.0
+
+// Limits on how the generator lays out a dungeon, in cells
+const (
+	dungeonRoomAttempts = 30 // Tries per room before giving up on fitting more in
+	dungeonRoomGap      = 2  // Solid cells kept between neighbouring rooms
+	dungeonCorridorSize = 2  // Corridors are this many cells wide
+	dungeonSpawnMargin  = 1  // Enemies stand at least this far in from a room's edge
+)
+
+// DungeonEnemy is an enemy the generator can place in a dungeon's rooms
+type DungeonEnemy struct {
+	EnemyType string
+	Name      string
+}
+
+// DungeonConfig describes the dungeon to generate. Sizes are in cells.
+type DungeonConfig struct {
+	Zone             string
+	Name             string
+	Width            int
+	Height           int
+	Rooms            int // Rooms to try to place; fewer fit when the grid is crowded
+	MinRoomSize      int
+	MaxRoomSize      int
+	MaxSpawnsPerRoom int
+	Enemies          []DungeonEnemy
+	EntranceTo       string // Zone the portal beside the entry leads back to, none when empty
+	ExitTo           string // Zone the portal in the last room leads on to, none when empty
+}
+
+// GeneratedDungeon is a dungeon laid out by GenerateDungeon
+type GeneratedDungeon struct {
+	Room   types.Room
+	Spawns []EnemySpawn
+}
+
+// dungeonRect is a rectangle on the generator's grid, in cells
+type dungeonRect struct {
+	x, y, width, height int
+}
+
+func (r dungeonRect) centerX() int { return r.x + r.width/2 }
+func (r dungeonRect) centerY() int { return r.y + r.height/2 }
+
+// overlaps reports whether two rooms come closer than gap cells to each other
+func (r dungeonRect) overlaps(other dungeonRect, gap int) bool {
+	return r.x-gap < other.x+other.width && r.x+r.width+gap > other.x &&
+		r.y-gap < other.y+other.height && r.y+r.height+gap > other.y
+}
+
+// GenerateDungeon lays out a dungeon of rooms joined by corridors. The same seed
+// and config always produce the same dungeon, walls, portals and spawns included.
+//
+// Rooms are placed at random where they fit and ordered west to east; each is joined
+// to the one before it, so every room can be reached. Players enter in the first room
+// and the exit is in the last. Every room but the first gets a pack of enemies.
+func GenerateDungeon(seed int64, config DungeonConfig) GeneratedDungeon {
+	rng := rand.New(rand.NewSource(seed))
+
+	solid := make([][]bool, config.Height)
+	for y := range solid {
+		solid[y] = make([]bool, config.Width)
+		for x := range solid[y] {
+			solid[y][x] = true
+		}
+	}
+
+	rooms := placeDungeonRooms(rng, config)
+	sort.SliceStable(rooms, func(i, j int) bool {
+		return rooms[i].centerX() < rooms[j].centerX()
+	})
+
+	for _, room := range rooms {
+		carveDungeonRect(solid, room)
+	}
+	for i := 1; i < len(rooms); i++ {
+		carveDungeonCorridor(rng, solid, rooms[i-1], rooms[i])
+	}
+
+	generated := GeneratedDungeon{
+		Room: types.Room{
+			Zone:  config.Zone,
+			Name:  config.Name,
+			Walls: dungeonWalls(solid),
+		},
+	}
+	if len(rooms) == 0 {
+		return generated
+	}
+
+	entrance := rooms[0]
+	entry := dungeonCellCenter(entrance.centerX(), entrance.centerY())
+	generated.Room.Entry = entry
+	generated.Room.Graveyard = entry
+	if config.EntranceTo != "" {
+		// Against the west side of the first room, clear of the entry point
+		generated.Room.Portals = append(generated.Room.Portals, dungeonPortal(entrance, entrance.x, config.EntranceTo, "Way Out"))
+	}
+
+	exit := rooms[len(rooms)-1]
+	if config.ExitTo != "" && len(rooms) > 1 {
+		// Against the east side of the last room
+		generated.Room.Portals = append(generated.Room.Portals, dungeonPortal(exit, exit.x+exit.width-1, config.ExitTo, "Way Onward"))
+	}
+
+	for i, room := range rooms[1:] {
+		generated.Spawns = append(generated.Spawns, dungeonRoomSpawns(rng, config, room, fmt.Sprintf("room_%d", i+1))...)
+	}
+	return generated
+}
+
+// placeDungeonRooms scatters up to config.Rooms rooms over the grid without letting them touch
+func placeDungeonRooms(rng *rand.Rand, config DungeonConfig) []dungeonRect {
+	var rooms []dungeonRect
+	sizeRange := config.MaxRoomSize - config.MinRoomSize + 1
+
+	for attempt := 0; attempt < config.Rooms*dungeonRoomAttempts && len(rooms) < config.Rooms; attempt++ {
+		width := config.MinRoomSize + rng.Intn(sizeRange)
+		height := config.MinRoomSize + rng.Intn(sizeRange)
+		// Keep a solid border of at least one cell around the whole dungeon
+		if width > config.Width-2 || height > config.Height-2 {
+			continue
+		}
+
+		candidate := dungeonRect{
+			x:      1 + rng.Intn(config.Width-width-1),
+			y:      1 + rng.Intn(config.Height-height-1),
+			width:  width,
+			height: height,
+		}
+
+		fits := true
+		for _, room := range rooms {
+			if candidate.overlaps(room, dungeonRoomGap) {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			rooms = append(rooms, candidate)
+		}
+	}
+	return rooms
+}
+
+// carveDungeonRect clears every cell of a rectangle, clipped to the grid's solid border
+func carveDungeonRect(solid [][]bool, rect dungeonRect) {
+	for y := max(rect.y, 1); y < min(rect.y+rect.height, len(solid)-1); y++ {
+		for x := max(rect.x, 1); x < min(rect.x+rect.width, len(solid[y])-1); x++ {
+			solid[y][x] = false
+		}
+	}
+}
+
+// carveDungeonCorridor joins the centers of two rooms with an L-shaped corridor,
+// turning either horizontally or vertically first
+func carveDungeonCorridor(rng *rand.Rand, solid [][]bool, from, to dungeonRect) {
+	x1, y1 := from.centerX(), from.centerY()
+	x2, y2 := to.centerX(), to.centerY()
+
+	horizontal := func(y, xa, xb int) {
+		carveDungeonRect(solid, dungeonRect{x: min(xa, xb), y: y, width: abs(xb-xa) + dungeonCorridorSize, height: dungeonCorridorSize})
+	}
+	vertical := func(x, ya, yb int) {
+		carveDungeonRect(solid, dungeonRect{x: x, y: min(ya, yb), width: dungeonCorridorSize, height: abs(yb-ya) + dungeonCorridorSize})
+	}
+
+	if rng.Intn(2) == 0 {
+		horizontal(y1, x1, x2)
+		vertical(x2, y1, y2)
+	} else {
+		vertical(x1, y1, y2)
+		horizontal(y2, x1, x2)
+	}
+}
+
+// dungeonWalls turns the solid cells of the grid into as few wall rectangles as it
+// can, growing each wall right along its row and then down while whole rows match
+func dungeonWalls(solid [][]bool) []types.Wall {
+	covered := make([][]bool, len(solid))
+	for y := range covered {
+		covered[y] = make([]bool, len(solid[y]))
+	}
+	free := func(x, y int) bool { return solid[y][x] && !covered[y][x] }
+
+	var walls []types.Wall
+	for y := range solid {
+		for x := range solid[y] {
+			if !free(x, y) {
+				continue
+			}
+
+			width := 1
+			for x+width < len(solid[y]) && free(x+width, y) {
+				width++
+			}
+
+			height := 1
+		grow:
+			for y+height < len(solid) {
+				for dx := 0; dx < width; dx++ {
+					if !free(x+dx, y+height) {
+						break grow
+					}
+				}
+				height++
+			}
+
+			for dy := 0; dy < height; dy++ {
+				for dx := 0; dx < width; dx++ {
+					covered[y+dy][x+dx] = true
+				}
+			}
+			walls = append(walls, types.Wall{
+				X:      float64(x) * DungeonCellSize,
+				Y:      float64(y) * DungeonCellSize,
+				Width:  float64(width) * DungeonCellSize,
+				Height: float64(height) * DungeonCellSize,
+			})
+		}
+	}
+	return walls
+}
+
+// dungeonPortal places a portal one cell wide and two tall in a room's column x,
+// level with the room's center
+func dungeonPortal(room dungeonRect, x int, toZone, label string) types.Portal {
+	return types.Portal{
+		X:      float64(x) * DungeonCellSize,
+		Y:      float64(room.centerY()-1) * DungeonCellSize,
+		Width:  DungeonCellSize,
+		Height: 2 * DungeonCellSize,
+		ToZone: toZone,
+		Label:  label,
+	}
+}
+
+// dungeonRoomSpawns places a pack of one to MaxSpawnsPerRoom enemies on separate
+// cells of a room, away from its edges and doorways
+func dungeonRoomSpawns(rng *rand.Rand, config DungeonConfig, room dungeonRect, pack string) []EnemySpawn {
+	if len(config.Enemies) == 0 || config.MaxSpawnsPerRoom <= 0 {
+		return nil
+	}
+
+	var cells []types.Point
+	for y := room.y + dungeonSpawnMargin; y < room.y+room.height-dungeonSpawnMargin; y++ {
+		for x := room.x + dungeonSpawnMargin; x < room.x+room.width-dungeonSpawnMargin; x++ {
+			cells = append(cells, types.Point{X: float64(x), Y: float64(y)})
+		}
+	}
+	rng.Shuffle(len(cells), func(i, j int) { cells[i], cells[j] = cells[j], cells[i] })
+
+	count := min(1+rng.Intn(config.MaxSpawnsPerRoom), len(cells))
+	spawns := make([]EnemySpawn, 0, count)
+	for _, cell := range cells[:count] {
+		enemy := config.Enemies[rng.Intn(len(config.Enemies))]
+		position := dungeonCellCenter(int(cell.X), int(cell.Y))
+		spawns = append(spawns, EnemySpawn{
+			EnemyType: enemy.EnemyType,
+			Name:      enemy.Name,
+			X:         position.X,
+			Y:         position.Y,
+			Idle:      types.IdleBehavior{Kind: types.IdleStationary},
+			Pack:      pack,
+		})
+	}
+	return spawns
+}
+
+// dungeonCellCenter returns the pixel position of the middle of a cell
+func dungeonCellCenter(x, y int) types.Point {
+	return types.Point{
+		X: (float64(x) + 0.5) * DungeonCellSize,
+		Y: (float64(y) + 0.5) * DungeonCellSize,
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
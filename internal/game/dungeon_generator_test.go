@@ -0,0 +1,104 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// reachabilityStep is the grid, in pixels, the flood fill walks over
+const reachabilityStep = 10.0
+
+// reachable records the positions an entity can walk to, on a reachabilityStep grid
+type reachable struct {
+	columns int
+	seen    []bool
+}
+
+// floodFill finds every position reachable from start without passing through a wall
+func floodFill(room types.Room, config DungeonConfig, start types.Point) reachable {
+	columns := int(float64(config.Width) * DungeonCellSize / reachabilityStep)
+	rows := int(float64(config.Height) * DungeonCellSize / reachabilityStep)
+	r := reachable{columns: columns, seen: make([]bool, columns*rows)}
+
+	first := int(start.Y/reachabilityStep)*columns + int(start.X/reachabilityStep)
+	r.seen[first] = true
+	queue := []int{first}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		x, y := cell%columns, cell/columns
+		for _, step := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			nx, ny := x+step[0], y+step[1]
+			if nx < 0 || ny < 0 || nx >= columns || ny >= rows {
+				continue
+			}
+			next := ny*columns + nx
+			centerX := (float64(nx) + 0.5) * reachabilityStep
+			centerY := (float64(ny) + 0.5) * reachabilityStep
+			if r.seen[next] || CheckWallCollision(centerX, centerY, room.Walls) {
+				continue
+			}
+			r.seen[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return r
+}
+
+func (r reachable) contains(x, y float64) bool {
+	return r.seen[int(y/reachabilityStep)*r.columns+int(x/reachabilityStep)]
+}
+
+func TestGenerateDungeonIsReproducible(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		first := GenerateDungeon(seed, depthsConfig)
+		second := GenerateDungeon(seed, depthsConfig)
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("seed %d generated two different dungeons", seed)
+		}
+	}
+}
+
+func TestGenerateDungeonSeedChangesLayout(t *testing.T) {
+	first := GenerateDungeon(1, depthsConfig)
+	second := GenerateDungeon(2, depthsConfig)
+	if reflect.DeepEqual(first.Room.Walls, second.Room.Walls) {
+		t.Fatal("seeds 1 and 2 generated the same walls")
+	}
+}
+
+func TestGenerateDungeonIsConnected(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		dungeon := GenerateDungeon(seed, depthsConfig)
+		room := dungeon.Room
+
+		if len(room.Walls) == 0 || len(dungeon.Spawns) == 0 {
+			t.Fatalf("seed %d: %d walls and %d spawns, want some of each", seed, len(room.Walls), len(dungeon.Spawns))
+		}
+		if len(room.Portals) != 2 {
+			t.Fatalf("seed %d: %d portals, want an entrance and an exit", seed, len(room.Portals))
+		}
+
+		entry := room.Entry
+		if CheckWallCollision(entry.X, entry.Y, room.Walls) {
+			t.Fatalf("seed %d: entry (%.0f, %.0f) is inside a wall", seed, entry.X, entry.Y)
+		}
+		if portal := PortalAt(room, entry.X, entry.Y); portal != nil {
+			t.Fatalf("seed %d: entry (%.0f, %.0f) is inside the portal to %s", seed, entry.X, entry.Y, portal.ToZone)
+		}
+
+		fill := floodFill(room, depthsConfig, entry)
+		for _, spawn := range dungeon.Spawns {
+			if !fill.contains(spawn.X, spawn.Y) {
+				t.Fatalf("seed %d: %s at (%.0f, %.0f) cannot be reached from the entry", seed, spawn.Name, spawn.X, spawn.Y)
+			}
+		}
+		for _, portal := range room.Portals {
+			if !fill.contains(portal.X+portal.Width/2, portal.Y+portal.Height/2) {
+				t.Fatalf("seed %d: portal to %s cannot be reached from the entry", seed, portal.ToZone)
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// EncounterScript scripts a boss fight as a sequence of health-gated phases
+type EncounterScript struct {
+	Phases      []EncounterPhase // Phases[0] is active when the boss is pulled
+	EnrageAfter time.Duration    // Time in combat before the boss enrages; 0 never enrages
+	EnrageAura  string
+	EnrageYell  string
+	ResetYell   string // Said when the boss evades and resets
+}
+
+// EncounterPhase is one stage of a boss fight
+type EncounterPhase struct {
+	Name        string
+	BelowHealth float64 // Entered once the boss drops to this fraction of max health
+	Yell        string  // Said on entering the phase
+	Abilities   []EnemyAbility
+	Adds        []AddSpawn // Summoned on entering the phase
+}
+
+// AddSpawn is a group of enemies a boss summons
+type AddSpawn struct {
+	EnemyType string
+	Count     int
+}
+
+// wardenEncounter is the script for Warden Grimhollow, the dungeon boss
+var wardenEncounter = EncounterScript{
+	Phases: []EncounterPhase{
+		{
+			Name: "Halberd",
+			Yell: "None leave the Hollow!",
+			Abilities: []EnemyAbility{
+				wardenSunder,
+				wardenGroundSlam(12 * time.Second),
+			},
+		},
+		{
+			Name:        "Guards",
+			BelowHealth: 0.6,
+			Yell:        "Guards! Seize them!",
+			Abilities: []EnemyAbility{
+				wardenSunder,
+				wardenGroundSlam(8 * time.Second),
+			},
+			Adds: []AddSpawn{{EnemyType: "basic", Count: 2}},
+		},
+		{
+			Name:        "Desperation",
+			BelowHealth: 0.25,
+			Yell:        "I will not fall here!",
+			Abilities: []EnemyAbility{
+				{
+					ID:         "dark_mending",
+					Name:       "Dark Mending",
+					Kind:       EnemyHealSelf,
+					Cooldown:   30 * time.Second,
+					Conditions: AbilityConditions{BelowHealth: 0.25, Chance: 0.5},
+					HealPct:    0.1,
+				},
+				{
+					ID:       "frenzy",
+					Name:     "Frenzy",
+					Kind:     EnemyBuffSelf,
+					Cooldown: 25 * time.Second,
+					AuraID:   "frenzy",
+				},
+				wardenSunder,
+				wardenGroundSlam(6 * time.Second),
+			},
+		},
+	},
+	EnrageAfter: 3 * time.Minute,
+	EnrageAura:  "enrage",
+	EnrageYell:  "Enough! I will crush you all!",
+	ResetYell:   "The Hollow keeps its own.",
+}
+
+var wardenSunder = EnemyAbility{
+	ID:        "sunder",
+	Name:      "Sunder",
+	Kind:      EnemyStrike,
+	Cooldown:  8 * time.Second,
+	MinDamage: 8,
+	MaxDamage: 12,
+}
+
+func wardenGroundSlam(cooldown time.Duration) EnemyAbility {
+	return EnemyAbility{
+		ID:           "ground_slam",
+		Name:         "Ground Slam",
+		Kind:         EnemyGroundAoE,
+		Cooldown:     cooldown,
+		Conditions:   AbilityConditions{MaxRange: 300},
+		MinDamage:    18,
+		MaxDamage:    24,
+		Radius:       70,
+		Delay:        2 * time.Second,
+		RandomTarget: true,
+	}
+}
+
+// EncounterPhaseAt returns the phase a boss should be in at the given health. Phases
+// only advance; healing back above a threshold does not return to an earlier phase.
+func EncounterPhaseAt(script *EncounterScript, current int, healthPct float64) int {
+	phase := current
+	for i := current + 1; i < len(script.Phases); i++ {
+		if healthPct <= script.Phases[i].BelowHealth {
+			phase = i
+		}
+	}
+	return phase
+}
+
+// ShouldEnrage reports whether a boss engaged at engagedAt has run out its enrage timer
+func ShouldEnrage(script *EncounterScript, engagedAt, now time.Time) bool {
+	return script.EnrageAfter > 0 && !engagedAt.IsZero() && now.Sub(engagedAt) >= script.EnrageAfter
+}
+
+// EnemyEncounter returns the encounter script for a boss, or nil for ordinary enemies
+func EnemyEncounter(enemy *types.Enemy) *EncounterScript {
+	return enemyTemplates[enemy.EnemyType].Encounter
+}
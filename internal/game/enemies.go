@@ -0,0 +1,175 @@
+package game
+
+import (
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// EnemyTemplate describes an enemy type's attributes, gear and abilities
+type EnemyTemplate struct {
+	Name      string
+	Level     int
+	BaseStats types.StatModifiers
+	Weapon    types.Weapon // A fresh ID is assigned on spawn
+	Abilities []EnemyAbility
+	Encounter *EncounterScript // Set for bosses, whose phases replace Abilities
+	AI        AIConfig
+	Loot      LootTable
+}
+
+// enemyTemplates defines every enemy that can be spawned, keyed by enemy type
+var enemyTemplates = map[string]EnemyTemplate{
+	"basic": {
+		Name:      "Enemy",
+		Level:     1,
+		BaseStats: types.StatModifiers{Strength: 8, Agility: 5, Intellect: 0, Stamina: 4, Armor: 20},
+		Weapon: types.Weapon{
+			Name:       "Claws",
+			Damage:     10,
+			MinDamage:  8,
+			MaxDamage:  12,
+			Range:      1,
+			WeaponType: "melee",
+			Delay:      2 * time.Second,
+		},
+		Abilities: []EnemyAbility{
+			{
+				ID:         "maul",
+				Name:       "Maul",
+				Kind:       EnemyStrike,
+				Cooldown:   10 * time.Second,
+				Conditions: AbilityConditions{Chance: 0.3},
+				MinDamage:  4,
+				MaxDamage:  6,
+			},
+		},
+		AI: AIConfig{
+			LeashDistance: 400,
+			FleeBelow:     0.15,
+			FleeDuration:  4 * time.Second,
+		},
+		Loot: LootTable{
+			MinGold: 1,
+			MaxGold: 5,
+			Rolls:   2,
+			Entries: []LootEntry{
+				{Weight: 40},
+				{ItemID: "linen_cloth", Weight: 30, MinCount: 1, MaxCount: 3},
+				{ItemID: "tattered_hide", Weight: 20, MinCount: 1, MaxCount: 2},
+				{ItemID: "minor_healing_potion", Weight: 8, MinCount: 1},
+				{ItemID: "rusty_sword", Weight: 2, MinCount: 1},
+				{ItemID: "padded_vest", Weight: 2, MinCount: 1},
+				{ItemID: "frayed_leggings", Weight: 2, MinCount: 1},
+			},
+		},
+	},
+	"archer": {
+		Name:      "Archer",
+		Level:     2,
+		BaseStats: types.StatModifiers{Strength: 5, Agility: 10, Intellect: 0, Stamina: 3, Armor: 10},
+		Weapon: types.Weapon{
+			Name:       "Short Bow",
+			Damage:     7,
+			MinDamage:  5,
+			MaxDamage:  9,
+			Range:      8,
+			WeaponType: "bow",
+			Delay:      2500 * time.Millisecond,
+		},
+		Abilities: []EnemyAbility{
+			{
+				ID:         "aimed_shot",
+				Name:       "Aimed Shot",
+				Kind:       EnemyStrike,
+				Cooldown:   12 * time.Second,
+				Conditions: AbilityConditions{MinRange: 100},
+				MinDamage:  6,
+				MaxDamage:  10,
+			},
+			{
+				ID:         "first_aid",
+				Name:       "First Aid",
+				Kind:       EnemyHealSelf,
+				Cooldown:   30 * time.Second,
+				Conditions: AbilityConditions{BelowHealth: 0.4},
+				HealPct:    0.3,
+			},
+		},
+		AI: AIConfig{
+			LeashDistance:     400,
+			CallForHelpRadius: 200,
+		},
+		Loot: LootTable{
+			MinGold: 2,
+			MaxGold: 6,
+			Rolls:   2,
+			Entries: []LootEntry{
+				{Weight: 40},
+				{ItemID: "broken_arrow", Weight: 35, MinCount: 2, MaxCount: 5},
+				{ItemID: "linen_cloth", Weight: 15, MinCount: 1, MaxCount: 2},
+				{ItemID: "leather_cap", Weight: 10, MinCount: 1},
+				{ItemID: "soft_leather_boots", Weight: 6, MinCount: 1},
+				{ItemID: "minor_mana_potion", Weight: 6, MinCount: 1},
+				{ItemID: "elixir_of_minor_fortitude", Weight: 3, MinCount: 1},
+			},
+		},
+	},
+	"warden": {
+		Name:      "Warden Grimhollow",
+		Level:     5,
+		BaseStats: types.StatModifiers{Strength: 14, Agility: 6, Intellect: 0, Stamina: 30, Armor: 60},
+		Weapon: types.Weapon{
+			Name:       "Warden's Halberd",
+			Damage:     14,
+			MinDamage:  12,
+			MaxDamage:  16,
+			Range:      2,
+			WeaponType: "polearm",
+			Delay:      2500 * time.Millisecond,
+		},
+		Encounter: &wardenEncounter,
+		AI: AIConfig{
+			LeashDistance: 500,
+		},
+		Loot: LootTable{
+			MinGold: 40,
+			MaxGold: 60,
+			Rolls:   2,
+			Entries: []LootEntry{
+				{ItemID: "wardens_greathelm", Weight: 1, MinCount: 1},
+				{ItemID: "minor_healing_potion", Weight: 2, MinCount: 2, MaxCount: 3},
+				{ItemID: "minor_mana_potion", Weight: 2, MinCount: 2, MaxCount: 3},
+			},
+		},
+	},
+}
+
+// LookupEnemyTemplate returns the template for an enemy type
+func LookupEnemyTemplate(enemyType string) (EnemyTemplate, bool) {
+	template, exists := enemyTemplates[enemyType]
+	return template, exists
+}
+
+// EnemyBaseStats returns the attributes for an enemy type
+func EnemyBaseStats(enemyType string) types.StatModifiers {
+	return enemyTemplates[enemyType].BaseStats
+}
+
+// EnemyWeapon returns the weapon an enemy type spawns with; a fresh ID is assigned on spawn
+func EnemyWeapon(enemyType string) types.Weapon {
+	return enemyTemplates[enemyType].Weapon
+}
+
+// EnemyAbilities returns the abilities an enemy can use right now. Bosses use
+// the abilities of their current encounter phase.
+func EnemyAbilities(enemy *types.Enemy) []EnemyAbility {
+	template := enemyTemplates[enemy.EnemyType]
+	if template.Encounter == nil {
+		return template.Abilities
+	}
+	if enemy.Phase < 0 || enemy.Phase >= len(template.Encounter.Phases) {
+		return nil
+	}
+	return template.Encounter.Phases[enemy.Phase].Abilities
+}
@@ -0,0 +1,83 @@
+package game
+
+import "time"
+
+// EnemyAbilityKind is what an enemy ability does when it is used
+type EnemyAbilityKind string
+
+const (
+	EnemyStrike    EnemyAbilityKind = "strike"    // Weapon attack on the target with bonus damage
+	EnemyGroundAoE EnemyAbilityKind = "ground"    // Telegraphed circle that lands after a delay
+	EnemyHealSelf  EnemyAbilityKind = "heal_self" // Restores a share of the enemy's max health
+	EnemyBuffSelf  EnemyAbilityKind = "buff_self" // Applies AuraID to the enemy
+)
+
+// AbilityConditions limit when an enemy may use an ability. Zero values impose no limit.
+type AbilityConditions struct {
+	BelowHealth float64 // Only usable at or below this fraction of max health
+	MinRange    float64 // Target must be at least this many pixels away
+	MaxRange    float64 // Target must be within this many pixels; strikes default to weapon reach
+	Chance      float64 // Chance to use the ability on each check once everything else allows it
+}
+
+// EnemyAbility is a special attack or effect an enemy template can use
+type EnemyAbility struct {
+	ID           string
+	Name         string
+	Kind         EnemyAbilityKind
+	Cooldown     time.Duration
+	Conditions   AbilityConditions
+	MinDamage    int // Bonus damage for strikes, total damage for ground effects
+	MaxDamage    int
+	Radius       float64       // Ground effects only
+	Delay        time.Duration // Warning time before a ground effect lands
+	RandomTarget bool          // Ground effects land under a random player on the threat list
+	HealPct      float64       // Share of max health restored by self heals
+	AuraID       string        // Aura applied by self buffs
+}
+
+// EnemyAbilityContext is what an enemy knows about the fight when choosing an ability
+type EnemyAbilityContext struct {
+	HealthPct      float64 // Current health as a fraction of max health
+	TargetDistance float64 // Pixels to the current target
+	Reach          float64 // Weapon reach in pixels
+	LineOfSight    bool    // Whether the enemy can see its current target
+}
+
+// ChooseEnemyAbility returns the first ability, in template order, that is off
+// cooldown and whose conditions hold
+func ChooseEnemyAbility(rng RNG, abilities []EnemyAbility, cooldowns map[string]time.Time, context EnemyAbilityContext, now time.Time) (EnemyAbility, bool) {
+	for _, ability := range abilities {
+		if now.Before(cooldowns[ability.ID]) {
+			continue
+		}
+		if !ability.Conditions.Allow(ability.Kind, context) {
+			continue
+		}
+		if ability.Conditions.Chance > 0 && rng.Float64() >= ability.Conditions.Chance {
+			continue
+		}
+		return ability, true
+	}
+	return EnemyAbility{}, false
+}
+
+// Allow reports whether the health, distance and line of sight conditions hold.
+// Chance is rolled separately.
+func (c AbilityConditions) Allow(kind EnemyAbilityKind, context EnemyAbilityContext) bool {
+	if c.BelowHealth > 0 && context.HealthPct > c.BelowHealth {
+		return false
+	}
+	if kind == EnemyStrike && !context.LineOfSight {
+		return false
+	}
+	if context.TargetDistance < c.MinRange {
+		return false
+	}
+
+	maxRange := c.MaxRange
+	if maxRange == 0 && kind == EnemyStrike {
+		maxRange = context.Reach
+	}
+	return maxRange == 0 || context.TargetDistance <= maxRange
+}
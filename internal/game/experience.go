@@ -0,0 +1,57 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func (g *GameClient) processExperienceMessage(msg types.Message) {
+	switch msg.Type {
+	case types.MsgXPGain:
+		var gain types.XPGain
+		if err := json.Unmarshal(msg.Data, &gain); err != nil {
+			log.Printf("Error unmarshaling experience gain: %v", err)
+			return
+		}
+		g.addMessage(fmt.Sprintf("%s dies, you gain %d experience", gain.Source, gain.Amount))
+
+	case types.MsgLevelUp:
+		var levelData struct {
+			Level int `json:"level"`
+		}
+		if err := json.Unmarshal(msg.Data, &levelData); err != nil {
+			log.Printf("Error unmarshaling level up: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		if player, exists := g.players[msg.PlayerID]; exists {
+			player.Level = levelData.Level
+		}
+		name := g.entityName(msg.PlayerID)
+		g.mutex.Unlock()
+
+		if msg.PlayerID == g.localPlayerID {
+			g.addMessage(fmt.Sprintf("Congratulations, you have reached level %d!", levelData.Level))
+		} else {
+			g.addMessage(fmt.Sprintf("%s has reached level %d", name, levelData.Level))
+		}
+	}
+}
+
+// drawXPBar draws the local player's progress towards their next level as a thin bar
+func drawXPBar(screen *ebiten.Image, player *types.Player, x, y, width float64) {
+	needed := XPToNextLevel(player.Level)
+	if needed == 0 {
+		return
+	}
+
+	ebitenutil.DrawRect(screen, x, y, width, 4, color.RGBA{0x30, 0x10, 0x40, 0xFF})
+	ebitenutil.DrawRect(screen, x, y, width*float64(player.XP)/float64(needed), 4, color.RGBA{0xA0, 0x40, 0xE0, 0xFF})
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"encoding/json"
+	"image/color"
+	"log"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// receivedGroundEffect is a telegraphed area and when it will land, by the local clock
+type receivedGroundEffect struct {
+	effect     types.GroundEffect
+	receivedAt time.Time
+	landsAt    time.Time
+}
+
+func (g *GameClient) processGroundEffect(msg types.Message) {
+	var effect types.GroundEffect
+	if err := json.Unmarshal(msg.Data, &effect); err != nil {
+		log.Printf("Error unmarshaling ground effect: %v", err)
+		return
+	}
+
+	now := time.Now()
+	g.mutex.Lock()
+	g.groundEffects[effect.ID] = &receivedGroundEffect{
+		effect:     effect,
+		receivedAt: now,
+		landsAt:    now.Add(effect.Warning),
+	}
+	g.mutex.Unlock()
+}
+
+// pruneGroundEffects drops effects that have landed
+func (g *GameClient) pruneGroundEffects() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	now := time.Now()
+	for effectID, received := range g.groundEffects {
+		if now.After(received.landsAt) {
+			delete(g.groundEffects, effectID)
+		}
+	}
+}
+
+// drawGroundEffects draws each telegraph as an outlined circle that fills in
+// from the center as the moment it lands approaches
+func (g *GameClient) drawGroundEffects(screen *ebiten.Image) {
+	g.mutex.RLock()
+	effects := make([]receivedGroundEffect, 0, len(g.groundEffects))
+	for _, received := range g.groundEffects {
+		effects = append(effects, *received)
+	}
+	cameraX := g.cameraX
+	cameraY := g.cameraY
+	g.mutex.RUnlock()
+
+	now := time.Now()
+	for _, received := range effects {
+		progress := 1.0
+		if warning := received.landsAt.Sub(received.receivedAt); warning > 0 {
+			progress = min(1, float64(now.Sub(received.receivedAt))/float64(warning))
+		}
+
+		x := float32(received.effect.X - cameraX)
+		y := float32(received.effect.Y - cameraY)
+		radius := float32(received.effect.Radius)
+
+		vector.DrawFilledCircle(screen, x, y, radius, color.RGBA{0x60, 0x10, 0x10, 0x50}, true)
+		vector.DrawFilledCircle(screen, x, y, radius*float32(progress), color.RGBA{0xC0, 0x30, 0x20, 0x80}, true)
+		vector.StrokeCircle(screen, x, y, radius, 2, color.RGBA{0xFF, 0x50, 0x30, 0xFF}, true)
+	}
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// cursorWorldPosition returns the world coordinates under the mouse cursor
+func (g *GameClient) cursorWorldPosition() (float64, float64) {
+	mouseX, mouseY := ebiten.CursorPosition()
+	return float64(mouseX) + g.cameraX, float64(mouseY) + g.cameraY
+}
+
+// reticleInRange reports whether the reticle is close enough to the local player to cast
+func (g *GameClient) reticleInRange(slot *actionBarSlot, x, y float64) bool {
+	g.mutex.RLock()
+	localPlayer, exists := g.players[g.localPlayerID]
+	g.mutex.RUnlock()
+
+	if !exists {
+		return false
+	}
+	return math.Hypot(x-localPlayer.X, y-localPlayer.Y) <= slot.maxRange
+}
+
+// handleGroundTargetingInput places a pending ground-targeted ability with a left
+// click or cancels it with a right click or Escape. It reports whether a reticle
+// was active, so the click is not also used for selection.
+func (g *GameClient) handleGroundTargetingInput() bool {
+	slot := g.groundTargetSlot
+	if slot == nil {
+		return false
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.groundTargetSlot = nil
+		return true
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := g.cursorWorldPosition()
+		if !g.reticleInRange(slot, x, y) {
+			g.addMessage("Out of range")
+			return true
+		}
+
+		g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+			"action": slot.action,
+			"x":      x,
+			"y":      y,
+		})
+		g.groundTargetSlot = nil
+	}
+
+	return true
+}
+
+// drawGroundReticle draws the area a pending ground-targeted ability will cover
+func (g *GameClient) drawGroundReticle(screen *ebiten.Image) {
+	slot := g.groundTargetSlot
+	if slot == nil {
+		return
+	}
+
+	x, y := g.cursorWorldPosition()
+	reticleColor := color.RGBA{0x40, 0xC0, 0xFF, 0xFF}
+	if !g.reticleInRange(slot, x, y) {
+		reticleColor = color.RGBA{0xFF, 0x40, 0x40, 0xFF}
+	}
+
+	screenX := float32(x - g.cameraX)
+	screenY := float32(y - g.cameraY)
+	vector.DrawFilledCircle(screen, screenX, screenY, float32(slot.radius), color.RGBA{reticleColor.R / 4, reticleColor.G / 4, reticleColor.B / 4, 0x40}, true)
+	vector.StrokeCircle(screen, screenX, screenY, float32(slot.radius), 2, reticleColor, true)
+}
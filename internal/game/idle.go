@@ -0,0 +1,71 @@
+package game
+
+import (
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const idleArriveDistance = 2.0 // Pixels from a destination that count as having reached it
+
+// IdleDestination returns where an enemy with nobody to fight should walk this
+// tick, and false if it should stay put. Wanderers and patrollers advance to
+// their next point, pausing on arrival; everyone else walks back to their spawn.
+func IdleDestination(rng RNG, enemy *types.Enemy, now time.Time) (types.Point, bool) {
+	if now.Before(enemy.IdleUntil) {
+		return types.Point{}, false
+	}
+
+	idle := enemy.Idle
+	switch {
+	case idle.Kind == types.IdleWander && idle.WanderRadius > 0:
+		if enemy.IdleTarget == nil {
+			angle := rng.Float64() * 2 * math.Pi
+			distance := math.Sqrt(rng.Float64()) * idle.WanderRadius // Uniform over the circle's area
+			enemy.IdleTarget = &types.Point{
+				X: enemy.SpawnX + math.Cos(angle)*distance,
+				Y: enemy.SpawnY + math.Sin(angle)*distance,
+			}
+		}
+
+		if reachedPoint(enemy, *enemy.IdleTarget) {
+			enemy.IdleTarget = nil
+			enemy.IdleUntil = now.Add(idle.Pause + time.Duration(rng.Float64()*float64(idle.Pause)))
+			return types.Point{}, false
+		}
+		return *enemy.IdleTarget, true
+
+	case idle.Kind == types.IdlePatrol && len(idle.Waypoints) > 0:
+		waypoint := idle.Waypoints[enemy.WaypointIndex%len(idle.Waypoints)]
+		if reachedPoint(enemy, waypoint) {
+			enemy.WaypointIndex = (enemy.WaypointIndex + 1) % len(idle.Waypoints)
+			enemy.IdleUntil = now.Add(idle.Pause)
+			return types.Point{}, false
+		}
+		return waypoint, true
+	}
+
+	home := types.Point{X: enemy.SpawnX, Y: enemy.SpawnY}
+	return home, !reachedPoint(enemy, home)
+}
+
+// SkipIdleDestination gives up on a destination the enemy cannot reach, such as
+// a wander point behind a wall, and moves on as if it had arrived
+func SkipIdleDestination(enemy *types.Enemy, now time.Time) {
+	switch enemy.Idle.Kind {
+	case types.IdleWander:
+		enemy.IdleTarget = nil
+		enemy.IdleUntil = now.Add(enemy.Idle.Pause)
+	case types.IdlePatrol:
+		if len(enemy.Idle.Waypoints) > 0 {
+			enemy.WaypointIndex = (enemy.WaypointIndex + 1) % len(enemy.Idle.Waypoints)
+		}
+	}
+}
+
+func reachedPoint(enemy *types.Enemy, point types.Point) bool {
+	dx := point.X - enemy.X
+	dy := point.Y - enemy.Y
+	return math.Sqrt(dx*dx+dy*dy) <= idleArriveDistance
+}
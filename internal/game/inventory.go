@@ -0,0 +1,231 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// BagSize is how many slots every character's bag has
+const BagSize = 16
+
+// ErrBagFull is returned when there is no room in a bag for an item
+var ErrBagFull = errors.New("your bags are full")
+
+// EquipSlots lists the equipment slots in the order the paperdoll shows them
+var EquipSlots = []types.EquipSlot{
+	types.SlotHead,
+	types.SlotChest,
+	types.SlotLegs,
+	types.SlotFeet,
+	types.SlotMainHand,
+}
+
+// NewBag returns an empty bag. Empty slots are stacks with no item ID.
+func NewBag() []types.ItemStack {
+	return make([]types.ItemStack, BagSize)
+}
+
+// AddToBag adds items to a bag, topping up existing stacks before filling empty
+// slots. It returns how many of the items did not fit.
+func AddToBag(bag []types.ItemStack, item types.ItemStack) int {
+	maxStack := max(1, itemDefinitions[item.ItemID].MaxStack)
+
+	for i := range bag {
+		if item.Count == 0 {
+			break
+		}
+		if bag[i].ItemID != item.ItemID || bag[i].Count >= maxStack {
+			continue
+		}
+
+		added := min(item.Count, maxStack-bag[i].Count)
+		bag[i].Count += added
+		item.Count -= added
+	}
+
+	for i := range bag {
+		if item.Count == 0 {
+			break
+		}
+		if bag[i].ItemID != "" {
+			continue
+		}
+
+		added := min(item.Count, maxStack)
+		bag[i] = types.ItemStack{ItemID: item.ItemID, Count: added}
+		item.Count -= added
+	}
+
+	return item.Count
+}
+
+// EquipItem moves the item in a bag slot into its equipment slot. Anything
+// already worn there takes its place in the bag.
+func EquipItem(player *types.Player, bagSlot int) error {
+	stack, err := bagStack(player.Bag, bagSlot)
+	if err != nil {
+		return err
+	}
+
+	definition, exists := itemDefinitions[stack.ItemID]
+	if !exists || definition.Slot == "" {
+		return fmt.Errorf("%s cannot be equipped", ItemName(stack.ItemID))
+	}
+	if stack.Count != 1 {
+		return fmt.Errorf("cannot equip a stack of %d %s", stack.Count, definition.Name)
+	}
+
+	if player.Equipment == nil {
+		player.Equipment = make(map[types.EquipSlot]string)
+	}
+
+	player.Bag[bagSlot] = types.ItemStack{}
+	if previous := player.Equipment[definition.Slot]; previous != "" {
+		player.Bag[bagSlot] = types.ItemStack{ItemID: previous, Count: 1}
+	}
+	player.Equipment[definition.Slot] = definition.ID
+
+	return nil
+}
+
+// UnequipItem moves the item worn in an equipment slot into the bag. It goes into
+// bagSlot if that is empty, or the first empty slot when bagSlot is negative.
+func UnequipItem(player *types.Player, slot types.EquipSlot, bagSlot int) error {
+	itemID := player.Equipment[slot]
+	if itemID == "" {
+		return fmt.Errorf("nothing is equipped in %s", slot)
+	}
+
+	if bagSlot < 0 {
+		bagSlot = firstEmptySlot(player.Bag)
+		if bagSlot < 0 {
+			return ErrBagFull
+		}
+	} else if bagSlot >= len(player.Bag) {
+		return fmt.Errorf("bag slot %d does not exist", bagSlot)
+	} else if player.Bag[bagSlot].ItemID != "" {
+		// Dropping onto another item equips that item instead, if it fits the same slot
+		if definition, exists := itemDefinitions[player.Bag[bagSlot].ItemID]; exists && definition.Slot == slot {
+			return EquipItem(player, bagSlot)
+		}
+		return fmt.Errorf("bag slot %d is not empty", bagSlot)
+	}
+
+	player.Bag[bagSlot] = types.ItemStack{ItemID: itemID, Count: 1}
+	delete(player.Equipment, slot)
+
+	return nil
+}
+
+// DestroyItem throws away the whole stack in a bag slot, returning what was destroyed
+func DestroyItem(player *types.Player, bagSlot int) (types.ItemStack, error) {
+	stack, err := bagStack(player.Bag, bagSlot)
+	if err != nil {
+		return types.ItemStack{}, err
+	}
+
+	player.Bag[bagSlot] = types.ItemStack{}
+	return stack, nil
+}
+
+// MoveBagItem moves a stack to another bag slot. Stacks of the same item are
+// merged as far as they will go; anything else swaps places.
+func MoveBagItem(bag []types.ItemStack, from, to int) error {
+	if _, err := bagStack(bag, from); err != nil {
+		return err
+	}
+	if to < 0 || to >= len(bag) {
+		return fmt.Errorf("bag slot %d does not exist", to)
+	}
+	if from == to {
+		return nil
+	}
+
+	if bag[to].ItemID == bag[from].ItemID {
+		maxStack := max(1, itemDefinitions[bag[to].ItemID].MaxStack)
+		moved := min(bag[from].Count, maxStack-bag[to].Count)
+		if moved > 0 {
+			bag[to].Count += moved
+			bag[from].Count -= moved
+			if bag[from].Count == 0 {
+				bag[from] = types.ItemStack{}
+			}
+			return nil
+		}
+	}
+
+	bag[from], bag[to] = bag[to], bag[from]
+	return nil
+}
+
+// CountInBag returns how many of an item a bag holds across all its stacks
+func CountInBag(bag []types.ItemStack, itemID string) int {
+	count := 0
+	for _, stack := range bag {
+		if stack.ItemID == itemID {
+			count += stack.Count
+		}
+	}
+	return count
+}
+
+// TakeOneFromBag removes a single copy of an item from the last stack holding it,
+// so partial stacks are used up before full ones. It reports whether the bag had one.
+func TakeOneFromBag(bag []types.ItemStack, itemID string) bool {
+	for i := len(bag) - 1; i >= 0; i-- {
+		if bag[i].ItemID != itemID {
+			continue
+		}
+
+		bag[i].Count--
+		if bag[i].Count <= 0 {
+			bag[i] = types.ItemStack{}
+		}
+		return true
+	}
+	return false
+}
+
+// EquippedWeapon returns the attack stats of the weapon in the player's main hand,
+// or nil when they are fighting unarmed
+func EquippedWeapon(player *types.Player) *types.Weapon {
+	definition, exists := itemDefinitions[player.Equipment[types.SlotMainHand]]
+	if !exists || definition.Weapon == nil {
+		return nil
+	}
+
+	weapon := *definition.Weapon
+	weapon.ID = definition.ID
+	weapon.Name = definition.Name
+	return &weapon
+}
+
+// EquipmentStats totals the attribute bonuses of everything a player is wearing
+func EquipmentStats(equipment map[types.EquipSlot]string) types.StatModifiers {
+	var total types.StatModifiers
+	for _, itemID := range equipment {
+		total = addStats(total, itemDefinitions[itemID].Stats)
+	}
+	return total
+}
+
+func bagStack(bag []types.ItemStack, bagSlot int) (types.ItemStack, error) {
+	if bagSlot < 0 || bagSlot >= len(bag) {
+		return types.ItemStack{}, fmt.Errorf("bag slot %d does not exist", bagSlot)
+	}
+	if bag[bagSlot].ItemID == "" {
+		return types.ItemStack{}, fmt.Errorf("bag slot %d is empty", bagSlot)
+	}
+	return bag[bagSlot], nil
+}
+
+func firstEmptySlot(bag []types.ItemStack) int {
+	for i := range bag {
+		if bag[i].ItemID == "" {
+			return i
+		}
+	}
+	return -1
+}
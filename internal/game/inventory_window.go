@@ -0,0 +1,403 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	inventoryX            = 570
+	inventoryY            = 240
+	inventoryCellSize     = 32
+	inventoryCellGap      = 4
+	inventoryBagColumns   = 4
+	inventoryHeaderHeight = 22
+	inventoryPadding      = 8
+	inventoryBagX         = inventoryX + inventoryPadding + inventoryCellSize + 16 // The paperdoll column sits to the left of the bag
+	inventoryWidth        = inventoryBagX - inventoryX + inventoryBagColumns*(inventoryCellSize+inventoryCellGap) + inventoryPadding - inventoryCellGap
+)
+
+// itemKindColors tint item icons until items have sprites of their own
+var itemKindColors = map[ItemKind]color.RGBA{
+	ItemWeapon:     {0xB0, 0xB0, 0xC0, 0xFF},
+	ItemArmor:      {0x8B, 0x5A, 0x2B, 0xFF},
+	ItemConsumable: {0xC0, 0x30, 0x30, 0xFF},
+	ItemJunk:       {0x70, 0x70, 0x70, 0xFF},
+}
+
+// equipSlotLabels are shown in empty paperdoll slots
+var equipSlotLabels = map[types.EquipSlot]string{
+	types.SlotHead:     "Head",
+	types.SlotChest:    "Chest",
+	types.SlotLegs:     "Legs",
+	types.SlotFeet:     "Feet",
+	types.SlotMainHand: "Main Hand",
+}
+
+// inventoryDrag is an item picked up in the inventory window. It comes either from
+// a bag slot or from an equipment slot.
+type inventoryDrag struct {
+	bagSlot   int // -1 when dragging from the paperdoll
+	equipSlot types.EquipSlot
+	itemID    string
+}
+
+// bagCellBounds returns the screen rectangle of a bag slot
+func bagCellBounds(slot int) (x, y int) {
+	column := slot % inventoryBagColumns
+	row := slot / inventoryBagColumns
+	return inventoryBagX + column*(inventoryCellSize+inventoryCellGap),
+		inventoryY + inventoryHeaderHeight + row*(inventoryCellSize+inventoryCellGap)
+}
+
+// equipCellBounds returns the screen rectangle of the paperdoll slot at the given index of EquipSlots
+func equipCellBounds(index int) (x, y int) {
+	return inventoryX + inventoryPadding, inventoryY + inventoryHeaderHeight + index*(inventoryCellSize+inventoryCellGap)
+}
+
+func inventoryHeight() int {
+	rows := max(len(EquipSlots), (BagSize+inventoryBagColumns-1)/inventoryBagColumns)
+	return inventoryHeaderHeight + rows*(inventoryCellSize+inventoryCellGap) + inventoryPadding
+}
+
+// inventoryCellAt returns the bag slot or equipment slot under a screen point.
+// The bag slot is -1 and the equipment slot empty when neither is.
+func inventoryCellAt(x, y int) (int, types.EquipSlot) {
+	for slot := 0; slot < BagSize; slot++ {
+		cellX, cellY := bagCellBounds(slot)
+		if pointInRect(x, y, cellX, cellY, inventoryCellSize, inventoryCellSize) {
+			return slot, ""
+		}
+	}
+	for i, equipSlot := range EquipSlots {
+		cellX, cellY := equipCellBounds(i)
+		if pointInRect(x, y, cellX, cellY, inventoryCellSize, inventoryCellSize) {
+			return -1, equipSlot
+		}
+	}
+	return -1, ""
+}
+
+// toggleInventory opens or closes the inventory window, dropping anything being dragged
+func (g *GameClient) toggleInventory() {
+	g.showInventory = !g.showInventory
+	g.inventoryDrag = nil
+	g.pendingDestroySlot = -1
+}
+
+// handleInventoryInput drags items around the inventory window, equips or uses items
+// on right-click, places consumables on the action bar and asks before destroying items dropped outside the window. It
+// returns true when the input was meant for the window.
+func (g *GameClient) handleInventoryInput() bool {
+	if !g.showInventory {
+		return false
+	}
+
+	g.mutex.RLock()
+	localPlayer, exists := g.players[g.localPlayerID]
+	var bag []types.ItemStack
+	var equipment map[types.EquipSlot]string
+	if exists {
+		bag = localPlayer.Bag
+		equipment = localPlayer.Equipment
+	}
+	g.mutex.RUnlock()
+
+	if !exists {
+		return false
+	}
+
+	if g.pendingDestroySlot >= 0 {
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+				"action": "destroy_item",
+				"slot":   g.pendingDestroySlot,
+			})
+			g.pendingDestroySlot = -1
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+			g.pendingDestroySlot = -1
+		}
+		return true
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	overWindow := pointInRect(mouseX, mouseY, inventoryX, inventoryY, inventoryWidth, inventoryHeight())
+	bagSlot, equipSlot := inventoryCellAt(mouseX, mouseY)
+
+	if g.inventoryDrag != nil {
+		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+			g.dropInventoryItem(*g.inventoryDrag, overWindow, bagSlot, equipSlot)
+			g.inventoryDrag = nil
+		}
+		return true
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if !overWindow {
+			return false
+		}
+		if bagSlot >= 0 && bagSlot < len(bag) && bag[bagSlot].ItemID != "" {
+			g.inventoryDrag = &inventoryDrag{bagSlot: bagSlot, itemID: bag[bagSlot].ItemID}
+		} else if equipSlot != "" && equipment[equipSlot] != "" {
+			g.inventoryDrag = &inventoryDrag{bagSlot: -1, equipSlot: equipSlot, itemID: equipment[equipSlot]}
+		}
+		return true
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		if !overWindow {
+			return false
+		}
+		if bagSlot >= 0 && bagSlot < len(bag) && bag[bagSlot].ItemID != "" {
+			if definition, exists := LookupItem(bag[bagSlot].ItemID); exists && definition.Slot != "" {
+				g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+					"action": "equip",
+					"slot":   bagSlot,
+				})
+			} else if exists && definition.Use != nil {
+				g.useItem(definition.ID)
+			}
+		} else if equipSlot != "" && equipment[equipSlot] != "" {
+			g.sendUnequip(equipSlot, -1)
+		}
+		return true
+	}
+
+	return false
+}
+
+// dropInventoryItem sends the request matching where a dragged item was let go
+func (g *GameClient) dropInventoryItem(drag inventoryDrag, overWindow bool, bagSlot int, equipSlot types.EquipSlot) {
+	switch {
+	case !overWindow:
+		mouseX, mouseY := ebiten.CursorPosition()
+		if index := g.actionBarSlotAt(mouseX, mouseY); index >= 0 {
+			if definition, exists := LookupItem(drag.itemID); exists && definition.Use != nil {
+				g.placeActionBarItem(index, drag.itemID)
+			} else {
+				g.addMessage(fmt.Sprintf("%s cannot be placed on the action bar", ItemName(drag.itemID)))
+			}
+			return
+		}
+
+		// Only bag items can be destroyed, so worn gear has to be taken off first
+		if drag.bagSlot >= 0 {
+			g.pendingDestroySlot = drag.bagSlot
+		}
+
+	case bagSlot >= 0:
+		if drag.bagSlot >= 0 {
+			g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+				"action":  "move_item",
+				"slot":    drag.bagSlot,
+				"to_slot": bagSlot,
+			})
+		} else {
+			g.sendUnequip(drag.equipSlot, bagSlot)
+		}
+
+	case equipSlot != "" && drag.bagSlot >= 0:
+		if definition, exists := LookupItem(drag.itemID); !exists || definition.Slot != equipSlot {
+			g.addMessage(fmt.Sprintf("%s cannot be worn there", ItemName(drag.itemID)))
+			return
+		}
+		g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+			"action": "equip",
+			"slot":   drag.bagSlot,
+		})
+	}
+}
+
+func (g *GameClient) sendUnequip(slot types.EquipSlot, bagSlot int) {
+	g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+		"action":  "unequip",
+		"target":  slot,
+		"to_slot": bagSlot,
+	})
+}
+
+// drawInventory draws the paperdoll and bag, the item being dragged and the tooltip for the item under the cursor
+func (g *GameClient) drawInventory(screen *ebiten.Image) {
+	if !g.showInventory {
+		return
+	}
+
+	g.mutex.RLock()
+	localPlayer, exists := g.players[g.localPlayerID]
+	var bag []types.ItemStack
+	equipment := make(map[types.EquipSlot]string)
+	if exists {
+		bag = append(bag, localPlayer.Bag...)
+		for slot, itemID := range localPlayer.Equipment {
+			equipment[slot] = itemID
+		}
+	}
+	g.mutex.RUnlock()
+
+	if !exists {
+		return
+	}
+
+	height := inventoryHeight()
+	ebitenutil.DrawRect(screen, inventoryX, inventoryY, inventoryWidth, float64(height), color.RGBA{0x00, 0x00, 0x00, 0xD0})
+	drawRectBorder(screen, inventoryX, inventoryY, inventoryWidth, height, color.RGBA{0xC0, 0xA0, 0x40, 0xFF})
+
+	titleOpts := &text.DrawOptions{}
+	titleOpts.GeoM.Translate(inventoryX+inventoryPadding, inventoryY+4)
+	text.Draw(screen, "Inventory", g.fontFace, titleOpts)
+
+	dragged := g.inventoryDrag
+	for i, equipSlot := range EquipSlots {
+		x, y := equipCellBounds(i)
+		itemID := equipment[equipSlot]
+		if dragged != nil && dragged.bagSlot < 0 && dragged.equipSlot == equipSlot {
+			itemID = ""
+		}
+
+		if itemID == "" {
+			g.drawEmptyCell(screen, x, y, equipSlotLabels[equipSlot])
+		} else {
+			g.drawItemIcon(screen, x, y, types.ItemStack{ItemID: itemID, Count: 1})
+		}
+	}
+
+	for slot := 0; slot < BagSize; slot++ {
+		x, y := bagCellBounds(slot)
+		if slot >= len(bag) || bag[slot].ItemID == "" || (dragged != nil && dragged.bagSlot == slot) {
+			g.drawEmptyCell(screen, x, y, "")
+			continue
+		}
+		g.drawItemIcon(screen, x, y, bag[slot])
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	if dragged != nil {
+		g.drawItemIcon(screen, mouseX-inventoryCellSize/2, mouseY-inventoryCellSize/2, types.ItemStack{ItemID: dragged.itemID, Count: 1})
+	} else if bagSlot, equipSlot := inventoryCellAt(mouseX, mouseY); bagSlot >= 0 && bagSlot < len(bag) && bag[bagSlot].ItemID != "" {
+		g.drawItemTooltip(screen, mouseX, mouseY, bag[bagSlot].ItemID)
+	} else if equipSlot != "" && equipment[equipSlot] != "" {
+		g.drawItemTooltip(screen, mouseX, mouseY, equipment[equipSlot])
+	}
+
+	if g.pendingDestroySlot >= 0 && g.pendingDestroySlot < len(bag) {
+		g.drawDestroyPrompt(screen, bag[g.pendingDestroySlot])
+	}
+}
+
+func (g *GameClient) drawEmptyCell(screen *ebiten.Image, x, y int, label string) {
+	ebitenutil.DrawRect(screen, float64(x), float64(y), inventoryCellSize, inventoryCellSize, color.RGBA{0x20, 0x20, 0x20, 0xFF})
+	drawRectBorder(screen, x, y, inventoryCellSize, inventoryCellSize, color.RGBA{0x50, 0x50, 0x50, 0xFF})
+
+	if label != "" {
+		ebitenutil.DebugPrintAt(screen, label[:min(len(label), 4)], x+2, y+8)
+	}
+}
+
+// drawItemIcon draws an item as a square tinted by its kind, with its initials and stack size
+func (g *GameClient) drawItemIcon(screen *ebiten.Image, x, y int, stack types.ItemStack) {
+	definition, _ := LookupItem(stack.ItemID)
+	tint, exists := itemKindColors[definition.Kind]
+	if !exists {
+		tint = itemKindColors[ItemJunk]
+	}
+
+	ebitenutil.DrawRect(screen, float64(x), float64(y), inventoryCellSize, inventoryCellSize, tint)
+	drawRectBorder(screen, x, y, inventoryCellSize, inventoryCellSize, color.RGBA{0xC0, 0xA0, 0x40, 0xFF})
+
+	var initials strings.Builder
+	for _, word := range strings.Fields(ItemName(stack.ItemID)) {
+		if initials.Len() < 2 {
+			initials.WriteByte(word[0])
+		}
+	}
+	ebitenutil.DebugPrintAt(screen, initials.String(), x+4, y+2)
+
+	if stack.Count > 1 {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", stack.Count), x+inventoryCellSize-14, y+inventoryCellSize-16)
+	}
+}
+
+// drawItemTooltip lists an item's name, slot and bonuses beside the cursor
+func (g *GameClient) drawItemTooltip(screen *ebiten.Image, mouseX, mouseY int, itemID string) {
+	definition, exists := LookupItem(itemID)
+	if !exists {
+		return
+	}
+
+	lines := []string{definition.Name}
+	if definition.Slot != "" {
+		lines = append(lines, equipSlotLabels[definition.Slot])
+	}
+	if weapon := definition.Weapon; weapon != nil {
+		lines = append(lines, fmt.Sprintf("%d - %d Damage  Speed %.1f", weapon.MinDamage, weapon.MaxDamage, weapon.Delay.Seconds()))
+	}
+	for _, stat := range []struct {
+		name  string
+		value int
+	}{
+		{"Armor", definition.Stats.Armor},
+		{"Strength", definition.Stats.Strength},
+		{"Agility", definition.Stats.Agility},
+		{"Intellect", definition.Stats.Intellect},
+		{"Stamina", definition.Stats.Stamina},
+	} {
+		if stat.value != 0 {
+			lines = append(lines, fmt.Sprintf("%+d %s", stat.value, stat.name))
+		}
+	}
+
+	if use := definition.Use; use != nil {
+		if use.MaxHeal > 0 {
+			lines = append(lines, fmt.Sprintf("Use: Restores %d - %d health", use.MinHeal, use.MaxHeal))
+		}
+		if use.MaxRestore > 0 {
+			lines = append(lines, fmt.Sprintf("Use: Restores %d - %d %s", use.MinRestore, use.MaxRestore, use.Resource))
+		}
+		if aura, exists := auraTemplates[use.AuraID]; exists {
+			lines = append(lines, fmt.Sprintf("Use: %s for %s", aura.Name, aura.Duration))
+		}
+		lines = append(lines, fmt.Sprintf("Cooldown: %s", use.Cooldown))
+	}
+
+	width := 220
+	height := len(lines)*15 + 8
+	x := min(mouseX+12, g.screenWidth-width)
+	y := min(mouseY+12, g.screenHeight-height)
+
+	ebitenutil.DrawRect(screen, float64(x), float64(y), float64(width), float64(height), color.RGBA{0x10, 0x10, 0x20, 0xF0})
+	drawRectBorder(screen, x, y, width, height, color.RGBA{0x80, 0x80, 0x80, 0xFF})
+
+	for i, line := range lines {
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(float64(x+6), float64(y+4+i*15))
+		text.Draw(screen, line, g.fontFace, opts)
+	}
+}
+
+func (g *GameClient) drawDestroyPrompt(screen *ebiten.Image, stack types.ItemStack) {
+	label := ItemName(stack.ItemID)
+	if stack.Count > 1 {
+		label = fmt.Sprintf("%s x%d", label, stack.Count)
+	}
+
+	width := 300
+	height := 50
+	x := (g.screenWidth - width) / 2
+	y := g.screenHeight/2 - 120
+
+	ebitenutil.DrawRect(screen, float64(x), float64(y), float64(width), float64(height), color.RGBA{0x00, 0x00, 0x00, 0xE0})
+	drawRectBorder(screen, x, y, width, height, color.RGBA{0xC0, 0x30, 0x30, 0xFF})
+
+	for i, line := range []string{"Destroy " + label + "?", "Enter to confirm, Esc to cancel"} {
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(float64(x+10), float64(y+8+i*18))
+		text.Draw(screen, line, g.fontFace, opts)
+	}
+}
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// ItemKind groups items by how they can be used
+type ItemKind string
+
+const (
+	ItemWeapon     ItemKind = "weapon"
+	ItemArmor      ItemKind = "armor"
+	ItemConsumable ItemKind = "consumable"
+	ItemJunk       ItemKind = "junk" // Only good for selling
+)
+
+// ItemDefinition describes every copy of an item
+type ItemDefinition struct {
+	ID       string
+	Name     string
+	Kind     ItemKind
+	MaxStack int                 // Most copies that fit in one bag slot
+	Slot     types.EquipSlot     // Where the item is worn; empty for items that cannot be equipped
+	Stats    types.StatModifiers // Attribute bonuses while equipped
+	Weapon   *types.Weapon       // Attack stats for weapons; the ID and name are filled in from the item
+	Use      *ItemUse            // What happens when a consumable is used
+}
+
+// ItemUse is the effect of using a consumable. Using one starts a cooldown shared
+// by every item in the same cooldown group.
+type ItemUse struct {
+	MinHeal       int
+	MaxHeal       int
+	Resource      types.ResourceType // Class resource restored; only characters using it can drink the item
+	MinRestore    int
+	MaxRestore    int
+	AuraID        string // Applied to the user
+	Cooldown      time.Duration
+	CooldownGroup string // Items sharing a group share a cooldown; defaults to the item ID
+}
+
+// itemDefinitions are all the items in the game, keyed by item ID
+var itemDefinitions = map[string]ItemDefinition{
+	"linen_cloth":   {ID: "linen_cloth", Name: "Linen Cloth", Kind: ItemJunk, MaxStack: 20},
+	"tattered_hide": {ID: "tattered_hide", Name: "Tattered Hide", Kind: ItemJunk, MaxStack: 20},
+	"broken_arrow":  {ID: "broken_arrow", Name: "Broken Arrow", Kind: ItemJunk, MaxStack: 20},
+
+	// Starting weapons, one per class
+	"wooden_sword": {
+		ID: "wooden_sword", Name: "Wooden Sword", Kind: ItemWeapon, MaxStack: 1, Slot: types.SlotMainHand,
+		Weapon: &types.Weapon{Damage: 5, MinDamage: 4, MaxDamage: 6, Range: 1, WeaponType: "sword", Delay: time.Second},
+	},
+	"apprentice_wand": {
+		ID: "apprentice_wand", Name: "Apprentice Wand", Kind: ItemWeapon, MaxStack: 1, Slot: types.SlotMainHand,
+		Weapon: &types.Weapon{Damage: 5, MinDamage: 4, MaxDamage: 6, Range: 8, WeaponType: "wand", Delay: 1500 * time.Millisecond},
+	},
+	"acolytes_mace": {
+		ID: "acolytes_mace", Name: "Acolyte's Mace", Kind: ItemWeapon, MaxStack: 1, Slot: types.SlotMainHand,
+		Weapon: &types.Weapon{Damage: 4, MinDamage: 3, MaxDamage: 5, Range: 1, WeaponType: "mace", Delay: 2 * time.Second},
+	},
+	"worn_dagger": {
+		ID: "worn_dagger", Name: "Worn Dagger", Kind: ItemWeapon, MaxStack: 1, Slot: types.SlotMainHand,
+		Weapon: &types.Weapon{Damage: 3, MinDamage: 2, MaxDamage: 4, Range: 1, WeaponType: "dagger", Delay: 1500 * time.Millisecond},
+	},
+
+	"rusty_sword": {
+		ID: "rusty_sword", Name: "Rusty Sword", Kind: ItemWeapon, MaxStack: 1, Slot: types.SlotMainHand,
+		Stats:  types.StatModifiers{Strength: 1},
+		Weapon: &types.Weapon{Damage: 7, MinDamage: 5, MaxDamage: 9, Range: 1, WeaponType: "sword", Delay: 1800 * time.Millisecond},
+	},
+	"leather_cap": {
+		ID: "leather_cap", Name: "Leather Cap", Kind: ItemArmor, MaxStack: 1, Slot: types.SlotHead,
+		Stats: types.StatModifiers{Stamina: 1, Armor: 10},
+	},
+	"padded_vest": {
+		ID: "padded_vest", Name: "Padded Vest", Kind: ItemArmor, MaxStack: 1, Slot: types.SlotChest,
+		Stats: types.StatModifiers{Stamina: 2, Armor: 15},
+	},
+	"frayed_leggings": {
+		ID: "frayed_leggings", Name: "Frayed Leggings", Kind: ItemArmor, MaxStack: 1, Slot: types.SlotLegs,
+		Stats: types.StatModifiers{Stamina: 1, Armor: 8},
+	},
+	"soft_leather_boots": {
+		ID: "soft_leather_boots", Name: "Soft Leather Boots", Kind: ItemArmor, MaxStack: 1, Slot: types.SlotFeet,
+		Stats: types.StatModifiers{Agility: 1, Armor: 6},
+	},
+	"wardens_greathelm": {
+		ID: "wardens_greathelm", Name: "Warden's Greathelm", Kind: ItemArmor, MaxStack: 1, Slot: types.SlotHead,
+		Stats: types.StatModifiers{Strength: 3, Stamina: 5, Armor: 40},
+	},
+
+	"minor_healing_potion": {
+		ID: "minor_healing_potion", Name: "Minor Healing Potion", Kind: ItemConsumable, MaxStack: 5,
+		Use: &ItemUse{MinHeal: 40, MaxHeal: 60, Cooldown: time.Minute, CooldownGroup: "potion"},
+	},
+	"minor_mana_potion": {
+		ID: "minor_mana_potion", Name: "Minor Mana Potion", Kind: ItemConsumable, MaxStack: 5,
+		Use: &ItemUse{Resource: types.ResourceMana, MinRestore: 60, MaxRestore: 80, Cooldown: time.Minute, CooldownGroup: "potion"},
+	},
+	"elixir_of_minor_fortitude": {
+		ID: "elixir_of_minor_fortitude", Name: "Elixir of Minor Fortitude", Kind: ItemConsumable, MaxStack: 5,
+		Use: &ItemUse{AuraID: "minor_fortitude", Cooldown: 3 * time.Second, CooldownGroup: "elixir"},
+	},
+}
+
+// StartingItems are put in the bag of every new character
+var StartingItems = []types.ItemStack{
+	{ItemID: "minor_healing_potion", Count: 2},
+}
+
+// LookupItem returns the definition of an item
+func LookupItem(itemID string) (ItemDefinition, bool) {
+	definition, exists := itemDefinitions[itemID]
+	return definition, exists
+}
+
+// ItemCooldownKey returns the key in Player.Cooldowns that tracks a consumable's cooldown group
+func ItemCooldownKey(itemID string) string {
+	definition, exists := itemDefinitions[itemID]
+	if !exists || definition.Use == nil || definition.Use.CooldownGroup == "" {
+		return "item:" + itemID
+	}
+	return "item:" + definition.Use.CooldownGroup
+}
+
+// ItemName returns an item's display name, falling back to its ID for unknown items
+func ItemName(itemID string) string {
+	if definition, exists := itemDefinitions[itemID]; exists {
+		return definition.Name
+	}
+	return itemID
+}
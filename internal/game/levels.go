@@ -0,0 +1,128 @@
+package game
+
+import (
+	"math"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	MaxLevel = 10
+
+	killXPBase          = 20
+	killXPPerLevel      = 10
+	killXPPerLevelAbove = 0.1  // Extra experience per level an enemy has over the player
+	killXPPerLevelBelow = 0.15 // Experience lost per level an enemy is below the player
+	maxXPLevelsAbove    = 4    // Enemies further above the player give no more than this
+	greyLevelGap        = 5    // Enemies this many levels below the player give no experience
+	bossXPMultiplier    = 5
+
+	missPerLevel      = 0.02 // Extra chance to miss a defender for each level they have over the attacker
+	damagePerLevel    = 0.05 // Damage gained or lost per level of difference between attacker and defender
+	minLevelDamageMod = 0.5
+	maxLevelDamageMod = 1.5
+)
+
+// xpToLevel is the experience needed to advance from each level to the next, indexed by level
+var xpToLevel = []int{0, 100, 250, 450, 700, 1000, 1400, 1900, 2500, 3200}
+
+// groupXPBonus multiplies the total experience of a kill shared by a group, indexed by the
+// number of players sharing it. A pair splits the solo amount evenly.
+var groupXPBonus = []float64{1, 1, 1, 1.1, 1.2, 1.3}
+
+// XPToNextLevel returns the experience needed to advance from the given level,
+// or 0 once a character has reached the level cap
+func XPToNextLevel(level int) int {
+	if level < 1 || level >= MaxLevel || level >= len(xpToLevel) {
+		return 0
+	}
+	return xpToLevel[level]
+}
+
+// KillXP returns the experience a player earns killing an enemy on their own.
+// Enemies above the player are worth more, and those far below are worth nothing.
+func KillXP(playerLevel, enemyLevel int, boss bool) int {
+	base := float64(killXPBase + killXPPerLevel*enemyLevel)
+
+	diff := enemyLevel - playerLevel
+	switch {
+	case diff >= 0:
+		base *= 1 + killXPPerLevelAbove*float64(min(diff, maxXPLevelsAbove))
+	case -diff >= greyLevelGap:
+		return 0
+	default:
+		base *= 1 + killXPPerLevelBelow*float64(diff)
+	}
+
+	if boss {
+		base *= bossXPMultiplier
+	}
+	return int(math.Round(base))
+}
+
+// GroupKillXP splits a kill's experience between the players who share it. Each
+// player's share is worked out against their own level. Groups of three or more
+// earn a bonus on the total that softens the split, but every member still gets
+// less per kill than they would solo; grouping pays off by killing faster.
+func GroupKillXP(enemyLevel int, boss bool, memberLevels []int) []int {
+	shares := make([]int, len(memberLevels))
+	if len(memberLevels) == 0 {
+		return shares
+	}
+
+	bonus := groupXPBonus[min(len(memberLevels), len(groupXPBonus)-1)]
+	for i, level := range memberLevels {
+		xp := float64(KillXP(level, enemyLevel, boss)) * bonus / float64(len(memberLevels))
+		shares[i] = int(math.Round(xp))
+	}
+	return shares
+}
+
+// GainXP adds experience to a player and levels them up as many times as it
+// pays for, raising their base attributes by their class's growth each time.
+// It returns the number of levels gained.
+func GainXP(player *types.Player, amount int) int {
+	if amount <= 0 || player.Level >= MaxLevel {
+		return 0
+	}
+
+	growth := classDefinitions[player.Class].StatsPerLevel
+	levels := 0
+
+	player.XP += amount
+	for player.Level < MaxLevel && player.XP >= XPToNextLevel(player.Level) {
+		player.XP -= XPToNextLevel(player.Level)
+		player.Level++
+		levels++
+
+		player.Strength += growth.Strength
+		player.Agility += growth.Agility
+		player.Intellect += growth.Intellect
+		player.Stamina += growth.Stamina
+		player.Armor += growth.Armor
+	}
+
+	if player.Level >= MaxLevel {
+		player.XP = 0
+	}
+	return levels
+}
+
+// levelMissChance returns the extra chance to miss a defender of a higher level
+func levelMissChance(attacker, defender types.DerivedStats) float64 {
+	if attacker.Level == 0 || defender.Level == 0 {
+		return 0
+	}
+	return missPerLevel * float64(max(0, defender.Level-attacker.Level))
+}
+
+// levelScaledDamage adjusts damage for the level difference between attacker and defender
+func levelScaledDamage(damage int, attacker, defender types.DerivedStats) int {
+	if attacker.Level == 0 || defender.Level == 0 {
+		return damage
+	}
+
+	modifier := 1 + damagePerLevel*float64(attacker.Level-defender.Level)
+	modifier = math.Max(minLevelDamageMod, math.Min(maxLevelDamageMod, modifier))
+	return max(1, int(math.Round(float64(damage)*modifier)))
+}
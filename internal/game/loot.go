@@ -0,0 +1,93 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// LootRange is how close a player must stand to a corpse to loot it, in pixels
+const LootRange = 60.0
+
+// LootEntry is one possible drop in a loot table
+type LootEntry struct {
+	ItemID   string // Empty for a roll that drops nothing
+	Weight   int    // Relative chance against the other entries
+	MinCount int
+	MaxCount int
+}
+
+// LootTable describes what an enemy drops when it dies
+type LootTable struct {
+	MinGold int
+	MaxGold int
+	Rolls   int // Weighted picks made from Entries
+	Entries []LootEntry
+}
+
+// RollLoot rolls a table's gold and item drops. Repeated drops of the same item are merged.
+func RollLoot(rng RNG, table LootTable) (int, []types.ItemStack) {
+	gold := table.MinGold
+	if table.MaxGold > table.MinGold {
+		gold += rng.Intn(table.MaxGold - table.MinGold + 1)
+	}
+
+	totalWeight := 0
+	for _, entry := range table.Entries {
+		totalWeight += entry.Weight
+	}
+
+	var items []types.ItemStack
+	for roll := 0; roll < table.Rolls && totalWeight > 0; roll++ {
+		pick := rng.Intn(totalWeight)
+		for _, entry := range table.Entries {
+			if pick >= entry.Weight {
+				pick -= entry.Weight
+				continue
+			}
+
+			if entry.ItemID != "" {
+				count := max(1, entry.MinCount)
+				if entry.MaxCount > count {
+					count += rng.Intn(entry.MaxCount - count + 1)
+				}
+				items = mergeStack(items, types.ItemStack{ItemID: entry.ItemID, Count: count})
+			}
+			break
+		}
+	}
+
+	return gold, items
+}
+
+// NextRoundRobinLooter picks the eligible player who has waited longest for a
+// corpse of their own. Ties go to the lowest player ID so the order is stable.
+func NextRoundRobinLooter(eligible []*types.Player) string {
+	if len(eligible) == 0 {
+		return ""
+	}
+
+	sorted := append([]*types.Player(nil), eligible...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].LastLootTurn.Equal(sorted[j].LastLootTurn) {
+			return sorted[i].LastLootTurn.Before(sorted[j].LastLootTurn)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted[0].ID
+}
+
+// EnemyLoot returns the loot table for an enemy type
+func EnemyLoot(enemyType string) LootTable {
+	return enemyTemplates[enemyType].Loot
+}
+
+func mergeStack(items []types.ItemStack, item types.ItemStack) []types.ItemStack {
+	for i := range items {
+		if items[i].ItemID == item.ItemID {
+			items[i].Count += item.Count
+			return items
+		}
+	}
+	return append(items, item)
+}
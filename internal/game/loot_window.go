@@ -0,0 +1,335 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"slices"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	lootWindowX      = 300
+	lootWindowY      = 140
+	lootWindowWidth  = 200
+	lootRowHeight    = 18
+	lootHeaderHeight = 22
+	lootButtonHeight = 20
+)
+
+// lootRuleLabels are the display names of the loot rules, in the order the toggle cycles through them
+var lootRuleLabels = []struct {
+	rule  types.LootRule
+	label string
+}{
+	{types.LootFreeForAll, "Free for all"},
+	{types.LootRoundRobin, "Round robin"},
+}
+
+func (g *GameClient) processLootMessage(msg types.Message) {
+	switch msg.Type {
+	case types.MsgCorpseSpawn:
+		var corpse types.Corpse
+		if err := json.Unmarshal(msg.Data, &corpse); err != nil {
+			log.Printf("Error unmarshaling corpse spawn: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		g.corpses[corpse.ID] = &corpse
+		g.mutex.Unlock()
+
+	case types.MsgCorpseRemove:
+		var removeData struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(msg.Data, &removeData); err != nil {
+			log.Printf("Error unmarshaling corpse removal: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		delete(g.corpses, removeData.ID)
+		if g.lootWindow != nil && g.lootWindow.CorpseID == removeData.ID {
+			g.lootWindow = nil
+		}
+		g.mutex.Unlock()
+
+	case types.MsgLootWindow:
+		var window types.LootWindow
+		if err := json.Unmarshal(msg.Data, &window); err != nil {
+			log.Printf("Error unmarshaling loot window: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		g.lootWindow = &window
+		g.mutex.Unlock()
+
+	case types.MsgLootRule:
+		var ruleData struct {
+			Rule types.LootRule `json:"rule"`
+		}
+		if err := json.Unmarshal(msg.Data, &ruleData); err != nil {
+			log.Printf("Error unmarshaling loot rule: %v", err)
+			return
+		}
+
+		// The rule itself arrives with the party update that follows
+		g.mutex.RLock()
+		setBy := g.entityName(msg.PlayerID)
+		g.mutex.RUnlock()
+		g.addMessage(fmt.Sprintf("%s set the loot rule to %s", setBy, lootRuleLabel(ruleData.Rule)))
+	}
+}
+
+func lootRuleLabel(rule types.LootRule) string {
+	for _, entry := range lootRuleLabels {
+		if entry.rule == rule {
+			return entry.label
+		}
+	}
+	return string(rule)
+}
+
+// cycleLootRule asks the server to switch the party to the next loot rule. Only
+// the party leader can change it; solo players always loot their own kills.
+func (g *GameClient) cycleLootRule() {
+	g.mutex.RLock()
+	party := g.party
+	isLeader := party != nil && party.LeaderID == g.localPlayerID
+	g.mutex.RUnlock()
+
+	switch {
+	case party == nil:
+		g.addMessage("You must be in a party to set a loot rule")
+		return
+	case !isLeader:
+		g.addMessage("Only the party leader can set the loot rule")
+		return
+	}
+	current := party.LootRule
+
+	next := lootRuleLabels[0].rule
+	for i, entry := range lootRuleLabels {
+		if entry.rule == current {
+			next = lootRuleLabels[(i+1)%len(lootRuleLabels)].rule
+		}
+	}
+
+	g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+		"action": "set_loot_rule",
+		"rule":   next,
+	})
+}
+
+func (g *GameClient) getCorpseAt(x, y float64) string {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	for corpseID, corpse := range g.corpses {
+		if x >= corpse.X-12 && x <= corpse.X+12 &&
+			y >= corpse.Y-8 && y <= corpse.Y+8 {
+			return corpseID
+		}
+	}
+	return ""
+}
+
+// openCorpse asks the server for a corpse's contents if the local player may loot it
+func (g *GameClient) openCorpse(corpseID string) {
+	g.mutex.RLock()
+	corpse, exists := g.corpses[corpseID]
+	localPlayer, playerExists := g.players[g.localPlayerID]
+	g.mutex.RUnlock()
+
+	if !exists || !playerExists {
+		return
+	}
+
+	if !slices.Contains(corpse.Looters, localPlayer.ID) {
+		g.addMessage("You cannot loot that corpse")
+		return
+	}
+
+	if math.Hypot(corpse.X-localPlayer.X, corpse.Y-localPlayer.Y) > LootRange {
+		g.addMessage("You are too far away to loot that")
+		return
+	}
+
+	g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+		"action": "loot_open",
+		"target": corpseID,
+	})
+}
+
+// closeLootWindowIfOutOfRange closes the loot window once the player walks away from the corpse
+func (g *GameClient) closeLootWindowIfOutOfRange() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.lootWindow == nil {
+		return
+	}
+
+	corpse, exists := g.corpses[g.lootWindow.CorpseID]
+	localPlayer, playerExists := g.players[g.localPlayerID]
+	if !exists || !playerExists || math.Hypot(corpse.X-localPlayer.X, corpse.Y-localPlayer.Y) > LootRange {
+		g.lootWindow = nil
+	}
+}
+
+// lootWindowHeight returns the height of the loot window for the given contents
+func lootWindowHeight(window *types.LootWindow) int {
+	rows := len(window.Items)
+	if window.Gold > 0 {
+		rows++
+	}
+	return lootHeaderHeight + rows*lootRowHeight + lootButtonHeight + 8
+}
+
+// handleLootWindowInput takes gold and items clicked in the loot window and closes
+// it on Escape. It returns true when the click was on the window.
+func (g *GameClient) handleLootWindowInput() bool {
+	g.mutex.RLock()
+	window := g.lootWindow
+	g.mutex.RUnlock()
+
+	if window == nil {
+		return false
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.mutex.Lock()
+		g.lootWindow = nil
+		g.mutex.Unlock()
+		return true
+	}
+
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return false
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	height := lootWindowHeight(window)
+	if !pointInRect(mouseX, mouseY, lootWindowX, lootWindowY, lootWindowWidth, height) {
+		return false
+	}
+
+	rowY := lootWindowY + lootHeaderHeight
+	if window.Gold > 0 {
+		if pointInRect(mouseX, mouseY, lootWindowX, rowY, lootWindowWidth, lootRowHeight) {
+			g.sendLootAction("loot_gold", window.CorpseID, 0)
+			return true
+		}
+		rowY += lootRowHeight
+	}
+
+	for slot := range window.Items {
+		if pointInRect(mouseX, mouseY, lootWindowX, rowY, lootWindowWidth, lootRowHeight) {
+			g.sendLootAction("loot_item", window.CorpseID, slot)
+			return true
+		}
+		rowY += lootRowHeight
+	}
+
+	// Take All: items are taken from the front, since each one taken shifts the rest up
+	if pointInRect(mouseX, mouseY, lootWindowX+5, rowY+4, lootWindowWidth-10, lootButtonHeight) {
+		if window.Gold > 0 {
+			g.sendLootAction("loot_gold", window.CorpseID, 0)
+		}
+		for range window.Items {
+			g.sendLootAction("loot_item", window.CorpseID, 0)
+		}
+	}
+	return true
+}
+
+func (g *GameClient) sendLootAction(action, corpseID string, slot int) {
+	g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+		"action": action,
+		"target": corpseID,
+		"slot":   slot,
+	})
+}
+
+// drawCorpses draws each corpse flat on the ground, with a gold glint on the ones the local player can loot
+func (g *GameClient) drawCorpses(screen *ebiten.Image) {
+	g.mutex.RLock()
+	corpses := make([]types.Corpse, 0, len(g.corpses))
+	for _, corpse := range g.corpses {
+		corpses = append(corpses, *corpse)
+	}
+	localPlayerID := g.localPlayerID
+	cameraX := g.cameraX
+	cameraY := g.cameraY
+	g.mutex.RUnlock()
+
+	for _, corpse := range corpses {
+		screenX := corpse.X - cameraX
+		screenY := corpse.Y - cameraY
+
+		ebitenutil.DrawRect(screen, screenX-12, screenY-6, 24, 12, color.RGBA{0x50, 0x50, 0x50, 0xFF})
+
+		if slices.Contains(corpse.Looters, localPlayerID) {
+			ebitenutil.DrawRect(screen, screenX-2, screenY-2, 4, 4, color.RGBA{0xFF, 0xD7, 0x00, 0xFF})
+		}
+	}
+}
+
+func (g *GameClient) drawLootWindow(screen *ebiten.Image) {
+	g.mutex.RLock()
+	window := g.lootWindow
+	corpseName := ""
+	if window != nil {
+		if corpse, exists := g.corpses[window.CorpseID]; exists {
+			corpseName = corpse.Name
+		}
+	}
+	g.mutex.RUnlock()
+
+	if window == nil {
+		return
+	}
+
+	height := lootWindowHeight(window)
+	ebitenutil.DrawRect(screen, lootWindowX, lootWindowY, lootWindowWidth, float64(height), color.RGBA{0x00, 0x00, 0x00, 0xD0})
+	drawRectBorder(screen, lootWindowX, lootWindowY, lootWindowWidth, height, color.RGBA{0xC0, 0xA0, 0x40, 0xFF})
+
+	opts := &text.DrawOptions{}
+	opts.GeoM.Translate(lootWindowX+5, lootWindowY+4)
+	text.Draw(screen, corpseName, g.fontFace, opts)
+
+	rowY := lootWindowY + lootHeaderHeight
+	if window.Gold > 0 {
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(lootWindowX+8, float64(rowY))
+		opts.ColorScale.ScaleWithColor(color.RGBA{0xFF, 0xD7, 0x00, 0xFF})
+		text.Draw(screen, fmt.Sprintf("%d gold", window.Gold), g.fontFace, opts)
+		rowY += lootRowHeight
+	}
+
+	for _, item := range window.Items {
+		label := ItemName(item.ItemID)
+		if item.Count > 1 {
+			label = fmt.Sprintf("%s x%d", label, item.Count)
+		}
+
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(lootWindowX+8, float64(rowY))
+		text.Draw(screen, label, g.fontFace, opts)
+		rowY += lootRowHeight
+	}
+
+	ebitenutil.DrawRect(screen, lootWindowX+5, float64(rowY+4), lootWindowWidth-10, lootButtonHeight, color.RGBA{0x40, 0x40, 0x40, 0xFF})
+	buttonOpts := &text.DrawOptions{}
+	buttonOpts.GeoM.Translate(lootWindowX+lootWindowWidth/2-25, float64(rowY+6))
+	text.Draw(screen, "Take All", g.fontFace, buttonOpts)
+}
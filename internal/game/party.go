@@ -0,0 +1,50 @@
+package game
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	MaxPartySize    = 5
+	PartyShareRange = 1000.0 // Party members further than this from a kill, in pixels, get no credit for it
+)
+
+// ErrPartyFull is returned when a party has no room for another member
+var ErrPartyFull = errors.New("the party is full")
+
+// PartyMemberIndex returns a player's position in a party, or -1 if they are not in it
+func PartyMemberIndex(party *types.Party, playerID string) int {
+	return slices.IndexFunc(party.Members, func(member types.PartyMember) bool {
+		return member.ID == playerID
+	})
+}
+
+// AddPartyMember adds a player to a party that has room for them
+func AddPartyMember(party *types.Party, player *types.Player) error {
+	if PartyMemberIndex(party, player.ID) >= 0 {
+		return nil
+	}
+	if len(party.Members) >= MaxPartySize {
+		return ErrPartyFull
+	}
+
+	party.Members = append(party.Members, types.PartyMember{ID: player.ID, Name: player.Name})
+	return nil
+}
+
+// RemovePartyMember takes a player out of a party. When the leader leaves, the
+// longest-standing member left takes over.
+func RemovePartyMember(party *types.Party, playerID string) {
+	index := PartyMemberIndex(party, playerID)
+	if index < 0 {
+		return
+	}
+
+	party.Members = slices.Delete(party.Members, index, index+1)
+	if party.LeaderID == playerID && len(party.Members) > 0 {
+		party.LeaderID = party.Members[0].ID
+	}
+}
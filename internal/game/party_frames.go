@@ -0,0 +1,299 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	partyFrameX       = 10
+	partyFrameY       = 30
+	partyFrameWidth   = 150
+	partyFrameHeight  = 38
+	partyFrameSpacing = 4
+	partyButtonHeight = 18
+	partyInviteWidth  = 260
+	partyInviteHeight = 60
+)
+
+func (g *GameClient) processPartyMessage(msg types.Message) {
+	switch msg.Type {
+	case types.MsgPartyInvite:
+		var invite types.PartyInvite
+		if err := json.Unmarshal(msg.Data, &invite); err != nil {
+			log.Printf("Error unmarshaling party invite: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		g.partyInvite = &invite
+		g.mutex.Unlock()
+
+		g.addMessage(fmt.Sprintf("%s invites you to join a party", invite.FromName))
+
+	case types.MsgPartyUpdate:
+		var party *types.Party
+		if err := json.Unmarshal(msg.Data, &party); err != nil {
+			log.Printf("Error unmarshaling party update: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		g.party = party
+		g.mutex.Unlock()
+
+	case types.MsgNotice:
+		var notice string
+		if err := json.Unmarshal(msg.Data, &notice); err != nil {
+			log.Printf("Error unmarshaling notice: %v", err)
+			return
+		}
+		g.addMessage(notice)
+	}
+}
+
+// invitePlayer asks the server to invite a player to the local player's party
+func (g *GameClient) invitePlayer(playerID string) {
+	if playerID == "" || playerID == g.localPlayerID {
+		return
+	}
+
+	g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+		"action": "party_invite",
+		"target": playerID,
+	})
+}
+
+// otherPartyMembers returns the party members other than the local player. Callers must hold the mutex.
+func (g *GameClient) otherPartyMembers() []types.PartyMember {
+	if g.party == nil {
+		return nil
+	}
+
+	members := make([]types.PartyMember, 0, len(g.party.Members))
+	for _, member := range g.party.Members {
+		if member.ID != g.localPlayerID {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func partyFrameBounds(index int) (x, y int) {
+	return partyFrameX, partyFrameY + index*(partyFrameHeight+partyFrameSpacing)
+}
+
+// partyKickBounds returns the small remove button the leader sees on each member's frame
+func partyKickBounds(index int) (x, y, width, height int) {
+	frameX, frameY := partyFrameBounds(index)
+	return frameX + partyFrameWidth - 14, frameY + 2, 12, 12
+}
+
+func (g *GameClient) partyInviteBounds() (x, y int) {
+	return (g.screenWidth - partyInviteWidth) / 2, 80
+}
+
+// handlePartyInput answers party invites and handles the leave and remove buttons
+// on the party frames. It returns true when the click was on one of them.
+func (g *GameClient) handlePartyInput() bool {
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return false
+	}
+	mouseX, mouseY := ebiten.CursorPosition()
+
+	g.mutex.RLock()
+	invite := g.partyInvite
+	members := g.otherPartyMembers()
+	isLeader := g.party != nil && g.party.LeaderID == g.localPlayerID
+	g.mutex.RUnlock()
+
+	if invite != nil {
+		x, y := g.partyInviteBounds()
+		buttonY := y + partyInviteHeight - partyButtonHeight - 6
+		buttonWidth := partyInviteWidth/2 - 15
+
+		action := ""
+		if pointInRect(mouseX, mouseY, x+10, buttonY, buttonWidth, partyButtonHeight) {
+			action = "party_accept"
+		} else if pointInRect(mouseX, mouseY, x+partyInviteWidth/2+5, buttonY, buttonWidth, partyButtonHeight) {
+			action = "party_decline"
+		}
+
+		if action != "" {
+			g.sendMessage(types.MsgPlayerAction, map[string]string{"action": action})
+			g.mutex.Lock()
+			g.partyInvite = nil
+			g.mutex.Unlock()
+			return true
+		}
+	}
+
+	if len(members) == 0 {
+		return false
+	}
+
+	for i, member := range members {
+		if x, y, width, height := partyKickBounds(i); isLeader && pointInRect(mouseX, mouseY, x, y, width, height) {
+			g.sendMessage(types.MsgPlayerAction, map[string]interface{}{
+				"action": "party_kick",
+				"target": member.ID,
+			})
+			return true
+		}
+
+		// Clicking a party frame targets that member, for healing spells
+		if x, y := partyFrameBounds(i); pointInRect(mouseX, mouseY, x, y, partyFrameWidth, partyFrameHeight) {
+			g.targetFriendlyID = member.ID
+			g.selectedEntityID = member.ID
+			g.selectedEntityType = "player"
+			return true
+		}
+	}
+
+	leaveX, leaveY := partyFrameBounds(len(members))
+	if pointInRect(mouseX, mouseY, leaveX, leaveY, partyFrameWidth, partyButtonHeight) {
+		g.sendMessage(types.MsgPlayerAction, map[string]string{"action": "party_leave"})
+		return true
+	}
+
+	return false
+}
+
+// partyMemberSnapshot is what a party frame shows of a member, copied out under the mutex
+type partyMemberSnapshot struct {
+	Health, MaxHealth int
+	Mana, MaxMana     int
+	Resource          types.ResourceType
+	Level             int
+	Dead              bool
+}
+
+// drawPartyFrames draws a frame with health, resource and dead status for each other party member
+func (g *GameClient) drawPartyFrames(screen *ebiten.Image) {
+	g.mutex.RLock()
+	members := g.otherPartyMembers()
+	isLeader := g.party != nil && g.party.LeaderID == g.localPlayerID
+	leaderID := ""
+	var lootRule types.LootRule
+	if g.party != nil {
+		leaderID = g.party.LeaderID
+		lootRule = g.party.LootRule
+	}
+	snapshots := make(map[string]partyMemberSnapshot, len(members))
+	for _, member := range members {
+		if player, exists := g.players[member.ID]; exists {
+			snapshots[member.ID] = partyMemberSnapshot{
+				Health:    player.Health,
+				MaxHealth: player.MaxHealth,
+				Mana:      player.Mana,
+				MaxMana:   player.MaxMana,
+				Resource:  player.Resource,
+				Level:     player.Level,
+				Dead:      player.Dead,
+			}
+		}
+	}
+	g.mutex.RUnlock()
+
+	if len(members) == 0 {
+		return
+	}
+
+	for i, member := range members {
+		x, y := partyFrameBounds(i)
+		ebitenutil.DrawRect(screen, float64(x), float64(y), partyFrameWidth, partyFrameHeight, color.RGBA{0x00, 0x00, 0x00, 0xB0})
+
+		frameColor := color.RGBA{0x40, 0xC0, 0x40, 0xFF}
+		if g.targetFriendlyID == member.ID {
+			frameColor = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+		}
+		drawRectBorder(screen, x, y, partyFrameWidth, partyFrameHeight, frameColor)
+
+		name := member.Name
+		if member.ID == leaderID {
+			name = "* " + name
+		}
+
+		snapshot, inRange := snapshots[member.ID]
+		if inRange {
+			name = fmt.Sprintf("%s (%d)", name, snapshot.Level)
+		}
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(float64(x+4), float64(y+2))
+		text.Draw(screen, name, g.fontFace, opts)
+
+		if isLeader {
+			kickX, kickY, kickWidth, kickHeight := partyKickBounds(i)
+			ebitenutil.DrawRect(screen, float64(kickX), float64(kickY), float64(kickWidth), float64(kickHeight), color.RGBA{0x80, 0x20, 0x20, 0xFF})
+			ebitenutil.DebugPrintAt(screen, "x", kickX+3, kickY-2)
+		}
+
+		barWidth := float64(partyFrameWidth - 8)
+		switch {
+		case !inRange:
+			ebitenutil.DebugPrintAt(screen, "Out of range", x+4, y+20)
+		case snapshot.Dead:
+			ebitenutil.DrawRect(screen, float64(x+4), float64(y+20), barWidth, 8, color.RGBA{0x40, 0x40, 0x40, 0xFF})
+			ebitenutil.DebugPrintAt(screen, "DEAD", x+partyFrameWidth/2-12, y+20)
+		default:
+			healthPercent := float64(snapshot.Health) / float64(max(1, snapshot.MaxHealth))
+			ebitenutil.DrawRect(screen, float64(x+4), float64(y+20), barWidth, 8, color.RGBA{0x80, 0x00, 0x00, 0xFF})
+			ebitenutil.DrawRect(screen, float64(x+4), float64(y+20), barWidth*healthPercent, 8, color.RGBA{0x00, 0xFF, 0x00, 0xFF})
+
+			_, resourceBgColor, resourceFgColor := resourceStyle(snapshot.Resource)
+			resourcePercent := float64(snapshot.Mana) / float64(max(1, snapshot.MaxMana))
+			ebitenutil.DrawRect(screen, float64(x+4), float64(y+30), barWidth, 4, resourceBgColor)
+			ebitenutil.DrawRect(screen, float64(x+4), float64(y+30), barWidth*resourcePercent, 4, resourceFgColor)
+		}
+	}
+
+	leaveX, leaveY := partyFrameBounds(len(members))
+	ebitenutil.DrawRect(screen, float64(leaveX), float64(leaveY), partyFrameWidth, partyButtonHeight, color.RGBA{0x40, 0x40, 0x40, 0xE0})
+	leaveOpts := &text.DrawOptions{}
+	leaveOpts.GeoM.Translate(float64(leaveX+partyFrameWidth/2-35), float64(leaveY+1))
+	text.Draw(screen, "Leave Party", g.fontFace, leaveOpts)
+
+	lootLabel := "Loot: " + lootRuleLabel(lootRule)
+	if isLeader {
+		lootLabel += " (O to change)"
+	}
+	ebitenutil.DebugPrintAt(screen, lootLabel, leaveX, leaveY+partyButtonHeight+4)
+}
+
+// drawPartyInvite shows an open party invitation with accept and decline buttons
+func (g *GameClient) drawPartyInvite(screen *ebiten.Image) {
+	g.mutex.RLock()
+	invite := g.partyInvite
+	g.mutex.RUnlock()
+
+	if invite == nil {
+		return
+	}
+
+	x, y := g.partyInviteBounds()
+	ebitenutil.DrawRect(screen, float64(x), float64(y), partyInviteWidth, partyInviteHeight, color.RGBA{0x00, 0x00, 0x00, 0xD0})
+	drawRectBorder(screen, x, y, partyInviteWidth, partyInviteHeight, color.RGBA{0xC0, 0xA0, 0x40, 0xFF})
+
+	opts := &text.DrawOptions{}
+	opts.GeoM.Translate(float64(x+10), float64(y+6))
+	text.Draw(screen, fmt.Sprintf("%s invites you to a party", invite.FromName), g.fontFace, opts)
+
+	buttonY := y + partyInviteHeight - partyButtonHeight - 6
+	buttonWidth := partyInviteWidth/2 - 15
+	for i, label := range []string{"Accept", "Decline"} {
+		buttonX := x + 10 + i*(partyInviteWidth/2-5)
+		ebitenutil.DrawRect(screen, float64(buttonX), float64(buttonY), float64(buttonWidth), partyButtonHeight, color.RGBA{0x40, 0x40, 0x40, 0xFF})
+
+		buttonOpts := &text.DrawOptions{}
+		buttonOpts.GeoM.Translate(float64(buttonX+buttonWidth/2-20), float64(buttonY+1))
+		text.Draw(screen, label, g.fontFace, buttonOpts)
+	}
+}
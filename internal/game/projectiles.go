@@ -0,0 +1,98 @@
+package game
+
+import (
+	"encoding/json"
+	"image/color"
+	"log"
+	"math"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// projectileColors tints projectiles by their style
+var projectileColors = map[string]color.RGBA{
+	"frostbolt": {0x80, 0xE0, 0xFF, 0xFF},
+	"arrow":     {0xC0, 0x90, 0x50, 0xFF},
+	"wand":      {0xD0, 0x80, 0xFF, 0xFF},
+}
+
+func (g *GameClient) processProjectileMessage(msg types.Message) {
+	switch msg.Type {
+	case types.MsgProjectileSpawn:
+		var projectile types.Projectile
+		if err := json.Unmarshal(msg.Data, &projectile); err != nil {
+			log.Printf("Error unmarshaling projectile spawn: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		g.projectiles[projectile.ID] = &projectile
+		g.mutex.Unlock()
+
+	case types.MsgProjectileRemove:
+		var removeData struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(msg.Data, &removeData); err != nil {
+			log.Printf("Error unmarshaling projectile removal: %v", err)
+			return
+		}
+
+		g.mutex.Lock()
+		delete(g.projectiles, removeData.ID)
+		g.mutex.Unlock()
+	}
+}
+
+// updateProjectiles moves projectiles toward their targets between server updates.
+// The server decides when they hit; this only keeps the animation smooth.
+func (g *GameClient) updateProjectiles() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	elapsed := 1.0 / float64(ebiten.TPS())
+	for _, projectile := range g.projectiles {
+		var targetX, targetY float64
+		if enemy, exists := g.enemies[projectile.TargetID]; exists {
+			targetX, targetY = enemy.X, enemy.Y
+		} else if player, exists := g.players[projectile.TargetID]; exists {
+			targetX, targetY = player.X, player.Y
+		} else {
+			continue
+		}
+
+		dx := targetX - projectile.X
+		dy := targetY - projectile.Y
+		distance := math.Sqrt(dx*dx + dy*dy)
+		step := projectile.Speed * elapsed
+		if distance <= step {
+			projectile.X, projectile.Y = targetX, targetY
+			continue
+		}
+
+		projectile.X += dx / distance * step
+		projectile.Y += dy / distance * step
+	}
+}
+
+func (g *GameClient) drawProjectiles(screen *ebiten.Image) {
+	g.mutex.RLock()
+	projectiles := make([]types.Projectile, 0, len(g.projectiles))
+	for _, projectile := range g.projectiles {
+		projectiles = append(projectiles, *projectile)
+	}
+	cameraX := g.cameraX
+	cameraY := g.cameraY
+	g.mutex.RUnlock()
+
+	for _, projectile := range projectiles {
+		projectileColor, exists := projectileColors[projectile.Style]
+		if !exists {
+			projectileColor = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+		}
+
+		vector.DrawFilledCircle(screen, float32(projectile.X-cameraX), float32(projectile.Y-cameraY), 4, projectileColor, true)
+	}
+}
@@ -0,0 +1,117 @@
+package game
+
+import (
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// MaxComboPoints is the most combo points a rogue can build on one target
+const MaxComboPoints = 5
+
+// ResourceConfig describes how a class's resource pool fills and drains.
+// Regeneration and decay are applied once per second.
+type ResourceConfig struct {
+	Type           types.ResourceType
+	FixedMax       int           // Size of the pool; 0 scales it with Intellect
+	StartsFull     bool          // New and revived characters begin with resource rather than an empty pool
+	RegenPerSecond int           // Flat amount restored each second
+	RegenPct       float64       // Fraction of the pool restored each second
+	RegenDelay     time.Duration // Regeneration pauses this long after spending
+	DecayPerSecond int           // Lost each second, for resources built up in combat
+	GainOnHit      int           // Generated by landing a melee swing
+	GainOnHitTaken int           // Generated by being hit in melee
+}
+
+var (
+	manaResource = ResourceConfig{
+		Type:       types.ResourceMana,
+		StartsFull: true,
+		RegenPct:   0.02,
+		RegenDelay: 5 * time.Second, // The five second rule
+	}
+	rageResource = ResourceConfig{
+		Type:           types.ResourceRage,
+		FixedMax:       100,
+		DecayPerSecond: 1,
+		GainOnHit:      5,
+		GainOnHitTaken: 3,
+	}
+	energyResource = ResourceConfig{
+		Type:           types.ResourceEnergy,
+		FixedMax:       100,
+		StartsFull:     true,
+		RegenPerSecond: 10,
+	}
+)
+
+// ClassResource returns the resource configuration for a class, defaulting to mana
+func ClassResource(class string) ResourceConfig {
+	if definition, exists := classDefinitions[class]; exists {
+		return definition.Resource
+	}
+	return manaResource
+}
+
+// MaxResource returns the size of a resource pool for the given derived stats
+func MaxResource(config ResourceConfig, derived types.DerivedStats) int {
+	if config.FixedMax > 0 {
+		return config.FixedMax
+	}
+	return derived.MaxMana
+}
+
+// RegenerateResource applies one second of regeneration or decay to a living
+// player's resource, reporting whether the amount changed
+func RegenerateResource(player *types.Player, config ResourceConfig, now time.Time) bool {
+	if player.Dead {
+		return false
+	}
+
+	previous := player.Mana
+
+	if config.DecayPerSecond > 0 {
+		player.Mana = max(0, player.Mana-config.DecayPerSecond)
+	}
+
+	if player.Mana < player.MaxMana && now.Sub(player.LastSpend) >= config.RegenDelay {
+		regen := config.RegenPerSecond
+		if config.RegenPct > 0 {
+			regen += max(1, int(float64(player.MaxMana)*config.RegenPct))
+		}
+		player.Mana = min(player.MaxMana, player.Mana+regen)
+	}
+
+	return player.Mana != previous
+}
+
+// GainResource adds resource up to the pool size, reporting whether anything was gained
+func GainResource(player *types.Player, amount int) bool {
+	if amount <= 0 || player.Mana >= player.MaxMana {
+		return false
+	}
+	player.Mana = min(player.MaxMana, player.Mana+amount)
+	return true
+}
+
+// AddComboPoints builds combo points on a target. Switching targets discards
+// the points built on the previous one.
+func AddComboPoints(player *types.Player, targetID string, points int) {
+	if player.ComboTargetID != targetID {
+		player.ComboTargetID = targetID
+		player.ComboPoints = 0
+	}
+	player.ComboPoints = min(MaxComboPoints, player.ComboPoints+points)
+}
+
+// SpendComboPoints consumes every combo point built on the target, returning how many there were
+func SpendComboPoints(player *types.Player, targetID string) int {
+	if player.ComboTargetID != targetID {
+		return 0
+	}
+
+	points := player.ComboPoints
+	player.ComboPoints = 0
+	player.ComboTargetID = ""
+	return points
+}
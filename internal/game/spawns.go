@@ -0,0 +1,138 @@
+package game
+
+import (
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// EnemySpawn places an enemy in the dungeon along with what it does out of combat
+type EnemySpawn struct {
+	EnemyType string
+	Name      string
+	X         float64
+	Y         float64
+	Idle      types.IdleBehavior
+	Pack      string // Enemies sharing a pack are pulled together
+}
+
+// patrolRoute is the loop walked by the patrolling pair. Each member walks it
+// shifted by its own offset so the pair keeps formation.
+var patrolRoute = []types.Point{
+	{X: 500, Y: 350},
+	{X: 500, Y: 600},
+	{X: 300, Y: 600},
+	{X: 300, Y: 350},
+}
+
+// DungeonSpawns returns the enemies placed in the dungeon when the server starts
+func DungeonSpawns() []EnemySpawn {
+	return []EnemySpawn{
+		{
+			EnemyType: "basic",
+			Name:      "Enemy",
+			X:         200,
+			Y:         200,
+			Idle:      types.IdleBehavior{Kind: types.IdleWander, WanderRadius: 80, Pause: 3 * time.Second},
+		},
+		{
+			EnemyType: "basic",
+			Name:      "Patroller",
+			X:         500,
+			Y:         350,
+			Idle:      patrol(0, 0),
+			Pack:      "patrol",
+		},
+		{
+			EnemyType: "basic",
+			Name:      "Patroller",
+			X:         530,
+			Y:         350,
+			Idle:      patrol(30, 0),
+			Pack:      "patrol",
+		},
+		{
+			EnemyType: "basic",
+			Name:      "Enemy",
+			X:         800,
+			Y:         500,
+			Idle:      types.IdleBehavior{Kind: types.IdleStationary},
+			Pack:      "camp",
+		},
+		{
+			EnemyType: "basic",
+			Name:      "Enemy",
+			X:         840,
+			Y:         530,
+			Idle:      types.IdleBehavior{Kind: types.IdleStationary},
+			Pack:      "camp",
+		},
+		{
+			// An archer behind the pillar so ranged combat and line of sight come into play
+			EnemyType: "archer",
+			Name:      "Archer",
+			X:         760,
+			Y:         160,
+			Idle:      types.IdleBehavior{Kind: types.IdleStationary},
+		},
+		{
+			EnemyType: "warden",
+			Name:      "Warden Grimhollow",
+			X:         1000,
+			Y:         740,
+			Idle:      types.IdleBehavior{Kind: types.IdleStationary},
+		},
+	}
+}
+
+// CryptSpawns returns the enemies placed in the crypt when the server starts
+func CryptSpawns() []EnemySpawn {
+	return []EnemySpawn{
+		{
+			EnemyType: "basic",
+			Name:      "Crypt Ghoul",
+			X:         360,
+			Y:         400,
+			Idle:      types.IdleBehavior{Kind: types.IdleWander, WanderRadius: 60, Pause: 4 * time.Second},
+		},
+		{
+			EnemyType: "basic",
+			Name:      "Crypt Ghoul",
+			X:         560,
+			Y:         380,
+			Idle:      types.IdleBehavior{Kind: types.IdleStationary},
+			Pack:      "crypt_ghouls",
+		},
+		{
+			EnemyType: "basic",
+			Name:      "Crypt Ghoul",
+			X:         590,
+			Y:         420,
+			Idle:      types.IdleBehavior{Kind: types.IdleStationary},
+			Pack:      "crypt_ghouls",
+		},
+		{
+			EnemyType: "archer",
+			Name:      "Skeletal Archer",
+			X:         850,
+			Y:         250,
+			Idle:      types.IdleBehavior{Kind: types.IdleStationary},
+		},
+		{
+			EnemyType: "archer",
+			Name:      "Skeletal Archer",
+			X:         850,
+			Y:         550,
+			Idle:      types.IdleBehavior{Kind: types.IdleStationary},
+		},
+	}
+}
+
+// patrol returns the patrol behavior for a member walking patrolRoute at an offset
+func patrol(offsetX, offsetY float64) types.IdleBehavior {
+	waypoints := make([]types.Point, len(patrolRoute))
+	for i, point := range patrolRoute {
+		waypoints[i] = types.Point{X: point.X + offsetX, Y: point.Y + offsetY}
+	}
+	return types.IdleBehavior{Kind: types.IdlePatrol, Waypoints: waypoints, Pause: 2 * time.Second}
+}
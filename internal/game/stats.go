@@ -0,0 +1,90 @@
+package game
+
+import (
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	baseHealth         = 50   // Health before Stamina is applied
+	healthPerStamina   = 5    // Max health gained per point of Stamina
+	attackPowerPerStr  = 2    // Attack power gained per point of Strength
+	attackPowerPerDmg  = 14.0 // Attack power needed for +1 damage per second of weapon delay
+	baseCritChance     = 0.05 // 5% critical strike chance before Agility
+	agilityPerCritPct  = 20.0 // Agility needed for +1% critical strike chance
+	baseMana           = 50   // Mana before Intellect is applied
+	manaPerIntellect   = 15   // Max mana gained per point of Intellect
+	spellPowerPerInt   = 1    // Spell power gained per point of Intellect
+	maxCritChance      = 0.5  // Critical strike chance can never exceed 50%
+	armorPerAgility    = 2    // Armor gained per point of Agility
+	baseMissChance     = 0.05 // 5% chance for any attack to miss
+	baseDodgeChance    = 0.05 // 5% dodge chance before Agility
+	agilityPerDodgePct = 20.0 // Agility needed for +1% dodge chance
+	baseParryChance    = 0.05 // 5% parry chance
+	maxAvoidance       = 0.3  // Dodge and parry chance can never exceed 30% each
+)
+
+// ClassBaseStats returns the starting attributes for a player class
+func ClassBaseStats(class string) types.StatModifiers {
+	return classDefinitions[class].BaseStats
+}
+
+// EffectivePlayerStats returns a player's attributes including equipment and aura modifiers
+func EffectivePlayerStats(player *types.Player) types.StatModifiers {
+	return addStats(addStats(types.StatModifiers{
+		Strength:  player.Strength,
+		Agility:   player.Agility,
+		Intellect: player.Intellect,
+		Stamina:   player.Stamina,
+		Armor:     player.Armor,
+	}, EquipmentStats(player.Equipment)), TotalStatModifiers(player.Auras))
+}
+
+// EffectiveEnemyStats returns an enemy's attributes including aura modifiers
+func EffectiveEnemyStats(enemy *types.Enemy) types.StatModifiers {
+	return addStats(types.StatModifiers{
+		Strength:  enemy.Strength,
+		Agility:   enemy.Agility,
+		Intellect: enemy.Intellect,
+		Stamina:   enemy.Stamina,
+		Armor:     enemy.Armor,
+	}, TotalStatModifiers(enemy.Auras))
+}
+
+// DeriveStats converts attributes into combat values
+func DeriveStats(stats types.StatModifiers) types.DerivedStats {
+	critChance := baseCritChance + float64(stats.Agility)/agilityPerCritPct/100
+	critChance = math.Max(0, math.Min(maxCritChance, critChance))
+
+	dodgeChance := baseDodgeChance + float64(stats.Agility)/agilityPerDodgePct/100
+	dodgeChance = math.Max(0, math.Min(maxAvoidance, dodgeChance))
+
+	return types.DerivedStats{
+		MaxHealth:   max(1, baseHealth+stats.Stamina*healthPerStamina),
+		AttackPower: max(0, stats.Strength*attackPowerPerStr),
+		CritChance:  critChance,
+		MaxMana:     max(0, baseMana+stats.Intellect*manaPerIntellect),
+		SpellPower:  max(0, stats.Intellect*spellPowerPerInt),
+		Armor:       max(0, stats.Armor+stats.Agility*armorPerAgility),
+		MissChance:  baseMissChance,
+		DodgeChance: dodgeChance,
+		ParryChance: baseParryChance,
+	}
+}
+
+// AttackPowerBonus returns the extra damage attack power adds to a swing of the given weapon delay
+func AttackPowerBonus(attackPower int, delay time.Duration) int {
+	return int(math.Round(float64(attackPower) / attackPowerPerDmg * delay.Seconds()))
+}
+
+func addStats(a, b types.StatModifiers) types.StatModifiers {
+	return types.StatModifiers{
+		Strength:  a.Strength + b.Strength,
+		Agility:   a.Agility + b.Agility,
+		Intellect: a.Intellect + b.Intellect,
+		Stamina:   a.Stamina + b.Stamina,
+		Armor:     a.Armor + b.Armor,
+	}
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	meleeOvertake  = 1.1 // Threat share a player in melee range needs to pull aggro
+	rangedOvertake = 1.3 // Threat share a player at range needs to pull aggro
+)
+
+// abilityThreatMultipliers scales the threat an ability generates per point of
+// damage or healing. Abilities not listed generate one threat per point.
+var abilityThreatMultipliers = map[string]float64{
+	"critical_strike": 1.5,
+	"rend":            1.5,
+	"frost_nova":      0.5,
+	"heal":            0.5,
+	"renew":           0.5,
+}
+
+// AbilityThreat returns the threat generated by dealing or healing amount with an ability
+func AbilityThreat(ability string, amount float64) float64 {
+	if multiplier, exists := abilityThreatMultipliers[ability]; exists {
+		return amount * multiplier
+	}
+	return amount
+}
+
+// ThreatCandidate is a living player an enemy could attack
+type ThreatCandidate struct {
+	ID      string
+	Threat  float64
+	InMelee bool // Within the enemy's melee range
+}
+
+// SelectThreatTarget picks who an enemy should attack. A player only takes
+// aggro from the current target by exceeding its threat by 10% in melee range
+// or 30% at range. Without a valid current target the highest threat wins.
+func SelectThreatTarget(currentID string, candidates []ThreatCandidate) string {
+	var current *ThreatCandidate
+	for i := range candidates {
+		if candidates[i].ID == currentID {
+			current = &candidates[i]
+			break
+		}
+	}
+
+	// Consider the highest threat first so the strongest challenger wins
+	sorted := make([]ThreatCandidate, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Threat > sorted[j].Threat })
+
+	if current == nil {
+		if len(sorted) == 0 || sorted[0].Threat <= 0 {
+			return ""
+		}
+		return sorted[0].ID
+	}
+
+	for _, challenger := range sorted {
+		if challenger.ID == current.ID {
+			continue
+		}
+
+		overtake := rangedOvertake
+		if challenger.InMelee {
+			overtake = meleeOvertake
+		}
+		if challenger.Threat > current.Threat*overtake {
+			return challenger.ID
+		}
+	}
+
+	return current.ID
+}
+
+// TopThreat returns the highest threat value in a threat list
+func TopThreat(threat map[string]float64) float64 {
+	var highest float64
+	for _, value := range threat {
+		highest = max(highest, value)
+	}
+	return highest
+}
+
+// ReduceThreat removes a fraction of a player's threat, returning the amount removed
+func ReduceThreat(threat map[string]float64, playerID string, fraction float64) float64 {
+	current, exists := threat[playerID]
+	if !exists {
+		return 0
+	}
+
+	removed := current * fraction
+	threat[playerID] = current - removed
+	return removed
+}
+
+// BuildThreatTable returns the top entries of an enemy's threat list, with each
+// player's threat as a percentage of the current target's
+func BuildThreatTable(enemyID, targetID string, candidates []ThreatCandidate, limit int) types.ThreatTable {
+	sorted := make([]ThreatCandidate, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Threat > sorted[j].Threat })
+
+	var targetThreat float64
+	for _, candidate := range sorted {
+		if candidate.ID == targetID {
+			targetThreat = candidate.Threat
+		}
+	}
+
+	table := types.ThreatTable{EnemyID: enemyID, TargetID: targetID}
+	for _, candidate := range sorted[:min(limit, len(sorted))] {
+		entry := types.ThreatEntry{
+			PlayerID: candidate.ID,
+			Threat:   candidate.Threat,
+			PullAt:   rangedOvertake * 100,
+		}
+		if candidate.InMelee {
+			entry.PullAt = meleeOvertake * 100
+		}
+		if targetThreat > 0 {
+			entry.Percent = candidate.Threat / targetThreat * 100
+		}
+		table.Entries = append(table.Entries, entry)
+	}
+
+	return table
+}
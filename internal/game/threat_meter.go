@@ -0,0 +1,135 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	threatTableTimeout = 2 * time.Second // Tables not refreshed for this long belong to finished fights
+	aggroWarningShare  = 0.9             // Warn once threat reaches 90% of the pull threshold
+	threatMeterX       = 600
+	threatMeterY       = 130
+	threatMeterWidth   = 180
+	threatRowHeight    = 16
+)
+
+// receivedThreatTable is an enemy's threat table and when the server last sent it
+type receivedThreatTable struct {
+	table      types.ThreatTable
+	receivedAt time.Time
+}
+
+func (g *GameClient) processThreatUpdate(msg types.Message) {
+	var table types.ThreatTable
+	if err := json.Unmarshal(msg.Data, &table); err != nil {
+		log.Printf("Error unmarshaling threat update: %v", err)
+		return
+	}
+
+	g.mutex.Lock()
+	g.threatTables[table.EnemyID] = &receivedThreatTable{table: table, receivedAt: time.Now()}
+	g.mutex.Unlock()
+}
+
+// pruneThreatTables drops tables for enemies that died or left combat
+func (g *GameClient) pruneThreatTables() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for enemyID, received := range g.threatTables {
+		if _, exists := g.enemies[enemyID]; !exists || time.Since(received.receivedAt) > threatTableTimeout {
+			delete(g.threatTables, enemyID)
+		}
+	}
+}
+
+// threatTableFor returns the latest threat table for an enemy. Callers must hold the mutex.
+func (g *GameClient) threatTableFor(enemyID string) (types.ThreatTable, bool) {
+	received, exists := g.threatTables[enemyID]
+	if !exists {
+		return types.ThreatTable{}, false
+	}
+	return received.table, true
+}
+
+// isAboutToPullAggro reports whether the local player is close to taking aggro
+// from the enemy's current target. Callers must hold the mutex.
+func (g *GameClient) isAboutToPullAggro(table types.ThreatTable) bool {
+	if table.TargetID == g.localPlayerID {
+		return false
+	}
+
+	for _, entry := range table.Entries {
+		if entry.PlayerID == g.localPlayerID {
+			return entry.Percent >= entry.PullAt*aggroWarningShare
+		}
+	}
+	return false
+}
+
+func (g *GameClient) drawThreatMeter(screen *ebiten.Image) {
+	g.mutex.RLock()
+	table, exists := g.threatTableFor(g.targetEnemyID)
+	enemyName := g.entityName(g.targetEnemyID)
+	names := make(map[string]string, len(table.Entries))
+	for _, entry := range table.Entries {
+		names[entry.PlayerID] = g.entityName(entry.PlayerID)
+	}
+	localPlayerID := g.localPlayerID
+	pulling := g.isAboutToPullAggro(table)
+	g.mutex.RUnlock()
+
+	if !exists || len(table.Entries) == 0 {
+		return
+	}
+
+	height := 22 + len(table.Entries)*threatRowHeight
+	ebitenutil.DrawRect(screen, threatMeterX, threatMeterY, threatMeterWidth, float64(height), color.RGBA{0x00, 0x00, 0x00, 0xA0})
+	drawRectBorder(screen, threatMeterX, threatMeterY, threatMeterWidth, height, color.RGBA{0x80, 0x80, 0x80, 0xFF})
+
+	opts := &text.DrawOptions{}
+	opts.GeoM.Translate(threatMeterX+5, threatMeterY+4)
+	text.Draw(screen, fmt.Sprintf("Threat: %s", enemyName), g.fontFace, opts)
+
+	barWidth := float64(threatMeterWidth - 10)
+	for i, entry := range table.Entries {
+		rowY := float64(threatMeterY + 20 + i*threatRowHeight)
+
+		barColor := color.RGBA{0x60, 0x60, 0x60, 0xFF}
+		switch {
+		case entry.PlayerID == table.TargetID:
+			barColor = color.RGBA{0xC0, 0x20, 0x20, 0xFF} // Red for whoever has aggro
+		case entry.PlayerID == localPlayerID && pulling:
+			barColor = color.RGBA{0xFF, 0x80, 0x00, 0xFF} // Orange when close to pulling
+		case entry.PlayerID == localPlayerID:
+			barColor = color.RGBA{0x30, 0x60, 0xC0, 0xFF}
+		}
+
+		fill := math.Min(1, entry.Percent/entry.PullAt)
+		ebitenutil.DrawRect(screen, threatMeterX+5, rowY, barWidth*fill, threatRowHeight-2, barColor)
+
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(threatMeterX+8, rowY)
+		text.Draw(screen, fmt.Sprintf("%s  %.0f%%", names[entry.PlayerID], entry.Percent), g.fontFace, opts)
+	}
+}
+
+// drawAggroGlow pulses an orange outline around a frame to warn that the local player is about to pull aggro
+func drawAggroGlow(screen *ebiten.Image, x, y, width, height int) {
+	pulse := 0.5 + 0.5*math.Sin(float64(time.Now().UnixMilli())/150)
+
+	for i := 1; i <= 3; i++ {
+		alpha := uint8((0.6 - 0.15*float64(i)) * pulse * 0xFF)
+		drawRectBorder(screen, x-i*2, y-i*2, width+i*4, height+i*4, color.NRGBA{0xFF, 0x70, 0x00, alpha})
+	}
+}
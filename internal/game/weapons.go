@@ -0,0 +1,53 @@
+package game
+
+import "github.com/CollinEMac/tarnation/internal/types"
+
+const (
+	pixelsPerRange     = 25   // Weapon.Range is measured in tiles of this many pixels
+	defaultWeaponReach = 30.0 // Reach in pixels for characters without a weapon
+)
+
+// rangedWeaponTypes fire projectiles instead of striking in melee
+var rangedWeaponTypes = map[string]bool{
+	"bow":  true,
+	"wand": true,
+}
+
+// WeaponReach returns how close a character must be to its target to attack, in pixels
+func WeaponReach(weapon *types.Weapon) float64 {
+	if weapon == nil {
+		return defaultWeaponReach
+	}
+	return float64(weapon.Range * pixelsPerRange)
+}
+
+// IsRangedWeapon reports whether a weapon fires projectiles
+func IsRangedWeapon(weapon *types.Weapon) bool {
+	return weapon != nil && rangedWeaponTypes[weapon.WeaponType]
+}
+
+// IsMagicWeapon reports whether a weapon's attacks are resolved as spells,
+// ignoring armor and unable to be dodged
+func IsMagicWeapon(weapon *types.Weapon) bool {
+	return weapon != nil && weapon.WeaponType == "wand"
+}
+
+// RollAutoAttack resolves an auto attack with the combat table for the weapon's type
+func RollAutoAttack(rng RNG, weapon *types.Weapon, damage int, attacker, defender types.DerivedStats) AttackRoll {
+	switch {
+	case IsMagicWeapon(weapon):
+		return RollSpellAttack(rng, damage, attacker, defender)
+	case IsRangedWeapon(weapon):
+		return RollRangedAttack(rng, damage, attacker, defender)
+	default:
+		return RollMeleeAttack(rng, damage, attacker, defender)
+	}
+}
+
+// ProjectileSpeed returns how fast a ranged weapon's shots travel, in pixels per second
+func ProjectileSpeed(weapon *types.Weapon) float64 {
+	if IsMagicWeapon(weapon) {
+		return 300
+	}
+	return 450
+}
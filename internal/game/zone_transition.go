@@ -0,0 +1,84 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// processZoneChange moves the local player into a new zone. Everything seen in the
+// old zone is forgotten; the server sends the new zone's players, enemies and corpses next.
+func (g *GameClient) processZoneChange(msg types.Message) {
+	var change types.ZoneChange
+	if err := json.Unmarshal(msg.Data, &change); err != nil {
+		log.Printf("Error unmarshaling zone change: %v", err)
+		return
+	}
+
+	g.mutex.Lock()
+	g.room = change.Room
+	for playerID, player := range g.players {
+		if playerID != g.localPlayerID {
+			delete(g.players, playerID)
+			continue
+		}
+		player.Zone = change.Room.Zone
+		player.X = change.X
+		player.Y = change.Y
+	}
+
+	g.enemies = make(map[string]*types.Enemy)
+	g.projectiles = make(map[string]*types.Projectile)
+	g.threatTables = make(map[string]*receivedThreatTable)
+	g.groundEffects = make(map[string]*receivedGroundEffect)
+	g.corpses = make(map[string]*types.Corpse)
+	g.chatBubbles = make(map[string]*chatBubble)
+	g.floatingTexts = nil
+	g.lootWindow = nil
+
+	g.targetEnemyID = ""
+	g.targetFriendlyID = ""
+	g.selectedEntityID = ""
+	g.selectedEntityType = ""
+	g.groundTargetSlot = nil
+	g.mutex.Unlock()
+
+	g.addMessage(fmt.Sprintf("Entering %s", change.Room.Name))
+}
+
+// drawPortals draws each portal in the room as a glowing doorway with where it leads above it
+func (g *GameClient) drawPortals(screen *ebiten.Image) {
+	g.mutex.RLock()
+	portals := g.room.Portals
+	cameraX := g.cameraX
+	cameraY := g.cameraY
+	g.mutex.RUnlock()
+
+	fillColor := color.RGBA{0x40, 0x60, 0xFF, 0x80}
+	borderColor := color.RGBA{0x90, 0xB0, 0xFF, 0xFF}
+	for _, portal := range portals {
+		screenX := portal.X - cameraX
+		screenY := portal.Y - cameraY
+		if screenX+portal.Width < 0 || screenX > float64(g.screenWidth) ||
+			screenY+portal.Height < 0 || screenY > float64(g.screenHeight) {
+			continue
+		}
+
+		ebitenutil.DrawRect(screen, screenX, screenY, portal.Width, portal.Height, fillColor)
+		drawRectBorder(screen, int(screenX), int(screenY), int(portal.Width), int(portal.Height), borderColor)
+
+		if portal.Label != "" {
+			labelWidth, _ := text.Measure(portal.Label, g.fontFace, 0)
+			opts := &text.DrawOptions{}
+			opts.GeoM.Translate(screenX+portal.Width/2-labelWidth/2, screenY-16)
+			opts.ColorScale.ScaleWithColor(borderColor)
+			text.Draw(screen, portal.Label, g.fontFace, opts)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// Zone IDs
+const (
+	DungeonZone = "dungeon"
+	CryptZone   = "crypt"
+	DepthsZone  = "depths"
+
+	StartingZone = DungeonZone // Where new characters enter the world
+)
+
+// ZoneDefinition is a separate area of the world with its own map and enemies
+type ZoneDefinition struct {
+	ID     string
+	Room   func() types.Room
+	Spawns func() []EnemySpawn
+}
+
+var zoneDefinitions = map[string]ZoneDefinition{
+	DungeonZone: {
+		ID:     DungeonZone,
+		Room:   CreateDungeonRoom,
+		Spawns: DungeonSpawns,
+	},
+	CryptZone: {
+		ID:     CryptZone,
+		Room:   CreateCryptRoom,
+		Spawns: CryptSpawns,
+	},
+	DepthsZone: {
+		ID: DepthsZone,
+		Room: func() types.Room {
+			return GenerateDungeon(DepthsSeed, depthsConfig).Room
+		},
+		Spawns: func() []EnemySpawn {
+			return GenerateDungeon(DepthsSeed, depthsConfig).Spawns
+		},
+	},
+}
+
+// DepthsSeed is the seed the depths are generated from. Changing it lays out a new
+// dungeon; keeping it regenerates exactly the same one.
+const DepthsSeed int64 = 7031
+
+// depthsConfig is the generated dungeon beneath the crypt. Its exit climbs back up to the dungeon.
+var depthsConfig = DungeonConfig{
+	Zone:             DepthsZone,
+	Name:             "The Depths",
+	Width:            48,
+	Height:           36,
+	Rooms:            9,
+	MinRoomSize:      5,
+	MaxRoomSize:      9,
+	MaxSpawnsPerRoom: 3,
+	Enemies: []DungeonEnemy{
+		{EnemyType: "basic", Name: "Cave Lurker"},
+		{EnemyType: "archer", Name: "Deep Archer"},
+	},
+	EntranceTo: CryptZone,
+	ExitTo:     DungeonZone,
+}
+
+// LookupZone returns the definition of a zone
+func LookupZone(zoneID string) (ZoneDefinition, bool) {
+	zone, exists := zoneDefinitions[zoneID]
+	return zone, exists
+}
+
+// ZoneIDs returns every zone in the world in a stable order
+func ZoneIDs() []string {
+	ids := make([]string, 0, len(zoneDefinitions))
+	for id := range zoneDefinitions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// PortalAt returns the portal in a room that a position stands in, or nil if there is none
+func PortalAt(room types.Room, x, y float64) *types.Portal {
+	for i := range room.Portals {
+		portal := &room.Portals[i]
+		if x >= portal.X && x <= portal.X+portal.Width && y >= portal.Y && y <= portal.Y+portal.Height {
+			return portal
+		}
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package networking
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/gorilla/websocket"
+)
+
+// Staff log in by sending one of these tokens with their character. Leaving a
+// variable unset means nobody can log in with that role.
+const (
+	gmTokenEnv    = "TARNATION_GM_TOKEN"
+	adminTokenEnv = "TARNATION_ADMIN_TOKEN"
+	auditLogEnv   = "TARNATION_AUDIT_LOG" // File admin commands are recorded in; stderr when unset
+)
+
+// newAuditLog opens the log every admin command is recorded in, whether it was allowed or not
+func newAuditLog() *log.Logger {
+	var out io.Writer = os.Stderr
+	if path := os.Getenv(auditLogEnv); path != "" {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			log.Printf("Could not open audit log %s, logging to stderr: %v", path, err)
+		} else {
+			out = file
+		}
+	}
+	return log.New(out, "AUDIT ", log.LstdFlags|log.LUTC)
+}
+
+// staffRole returns the role a login token grants
+func staffRole(token string) types.Role {
+	if token == "" {
+		return types.RolePlayer
+	}
+
+	for _, staff := range []struct {
+		env  string
+		role types.Role
+	}{
+		{adminTokenEnv, types.RoleAdmin},
+		{gmTokenEnv, types.RoleGM},
+	} {
+		expected := os.Getenv(staff.env)
+		if expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+			return staff.role
+		}
+	}
+	return types.RolePlayer
+}
+
+// handleAdminCommand runs a staff command if the player's role allows it.
+// Every attempt goes in the audit log along with its outcome.
+func (s *GameServer) handleAdminCommand(player *types.Player, command types.AdminCommand) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	name := strings.ToLower(command.Command)
+	args := strings.TrimSpace(command.Args)
+
+	definition, exists := game.LookupAdminCommand(name)
+	if !exists {
+		s.audit.Printf("%s (%s, role %q) ran unknown command %q %q", player.Name, player.ID, player.Role, name, args)
+		s.sendError(player, fmt.Sprintf("unknown command /%s", name))
+		return
+	}
+	if !game.HasRole(player.Role, definition.Role) {
+		s.audit.Printf("%s (%s, role %q) was denied /%s %q", player.Name, player.ID, player.Role, name, args)
+		log.Printf("Player %s attempted admin command /%s without permission", player.Name, name)
+		s.sendError(player, "you do not have permission to do that")
+		return
+	}
+
+	var result string
+	var err error
+	switch name {
+	case "spawn":
+		result, err = s.adminSpawn(player, args)
+	case "teleport":
+		result, err = s.adminTeleport(player, args)
+	case "sethealth":
+		result, err = s.adminSetHealth(player, args)
+	case "kick":
+		result, err = s.adminKick(player, args)
+	case "announce":
+		result, err = s.adminAnnounce(player, args)
+	case "reload":
+		result, err = s.adminReload()
+	}
+
+	if err != nil {
+		s.audit.Printf("%s (%s, role %q) ran /%s %q: failed: %v", player.Name, player.ID, player.Role, name, args, err)
+		s.sendError(player, fmt.Sprintf("%v (usage: %s)", err, definition.Usage))
+		return
+	}
+
+	s.audit.Printf("%s (%s, role %q) ran /%s %q: %s", player.Name, player.ID, player.Role, name, args, result)
+	s.sendNotice(player, result)
+}
+
+// adminSpawn spawns an enemy at the GM's feet
+func (s *GameServer) adminSpawn(gm *types.Player, args string) (string, error) {
+	enemyType, name, _ := strings.Cut(args, " ")
+	template, exists := game.LookupEnemyTemplate(enemyType)
+	if !exists {
+		return "", fmt.Errorf("unknown enemy type %q", enemyType)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = template.Name
+	}
+
+	enemy := s.spawnEnemy(gm.Zone, game.EnemySpawn{EnemyType: enemyType, Name: name, X: gm.X, Y: gm.Y})
+	return fmt.Sprintf("Spawned %s at (%.0f, %.0f)", enemy.Name, enemy.X, enemy.Y), nil
+}
+
+// adminTeleport moves a player to a position, or to the GM when no position is given
+func (s *GameServer) adminTeleport(gm *types.Player, args string) (string, error) {
+	targetName, x, y, hasPosition := splitTrailingPosition(args)
+	target, err := s.findPlayer(targetName)
+	if err != nil {
+		return "", err
+	}
+
+	if !hasPosition {
+		x, y = gm.X, gm.Y
+	}
+
+	// Without a position the target is brought to the GM, which may be in another zone
+	if !hasPosition && target.Zone != gm.Zone {
+		s.changeZone(target, gm.Zone, x, y)
+	} else {
+		if game.CheckWallCollision(x, y, s.rooms[target.Zone].Walls) {
+			return "", fmt.Errorf("(%.0f, %.0f) is inside a wall", x, y)
+		}
+
+		target.X = x
+		target.Y = y
+		s.broadcast <- types.Message{
+			Type:     types.MsgPlayerTeleport,
+			PlayerID: target.ID,
+			Data: s.marshal(map[string]float64{
+				"x": target.X,
+				"y": target.Y,
+			}),
+		}
+	}
+
+	if target.ID != gm.ID {
+		s.sendNotice(target, fmt.Sprintf("You have been teleported by %s", gm.Name))
+	}
+	return fmt.Sprintf("Teleported %s to (%.0f, %.0f)", target.Name, x, y), nil
+}
+
+// adminSetHealth sets a player's health, reviving them if they were dead. Setting it to 0 kills them.
+func (s *GameServer) adminSetHealth(gm *types.Player, args string) (string, error) {
+	cut := strings.LastIndex(args, " ")
+	if cut < 0 {
+		return "", errors.New("missing health")
+	}
+
+	health, err := strconv.Atoi(args[cut+1:])
+	if err != nil || health < 0 {
+		return "", fmt.Errorf("invalid health %q", args[cut+1:])
+	}
+
+	targetName := strings.TrimSpace(args[:cut])
+	target, err := s.findPlayer(targetName)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case health == 0 && target.Dead:
+		return fmt.Sprintf("%s is already dead", target.Name), nil
+	case health == 0:
+		s.killPlayer(target, gm.ID)
+		return fmt.Sprintf("Killed %s", target.Name), nil
+	case target.Dead:
+		s.revivePlayer(target, 1)
+	}
+
+	target.Health = min(health, target.MaxHealth)
+	s.broadcastPlayerUpdate(target)
+	return fmt.Sprintf("Set %s's health to %d/%d", target.Name, target.Health, target.MaxHealth), nil
+}
+
+// adminKick disconnects a player from the server
+func (s *GameServer) adminKick(gm *types.Player, args string) (string, error) {
+	target, err := s.findPlayer(args)
+	if err != nil {
+		return "", err
+	}
+	if target.ID == gm.ID {
+		return "", errors.New("you cannot kick yourself")
+	}
+
+	// Written directly rather than broadcast so it arrives before the connection closes
+	notice := types.Message{
+		Type: types.MsgNotice,
+		Data: s.marshal(fmt.Sprintf("You have been kicked from the server by %s", gm.Name)),
+	}
+	target.ConnMutex.Lock()
+	target.Conn.WriteJSON(notice)
+	target.Conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "kicked"))
+	target.ConnMutex.Unlock()
+
+	// Closing the connection ends the player's read loop, which removes them from the world
+	target.Conn.Close()
+	return fmt.Sprintf("Kicked %s", target.Name), nil
+}
+
+// adminAnnounce shows a server-wide announcement to every player
+func (s *GameServer) adminAnnounce(gm *types.Player, args string) (string, error) {
+	text := cleanChatText(args)
+	if text == "" {
+		return "", errors.New("missing message")
+	}
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgAnnouncement,
+		PlayerID: gm.ID,
+		Data:     s.marshal(text),
+	}
+	return "Announcement sent", nil
+}
+
+// adminReload rebuilds the world from its definitions: every zone's room is recreated,
+// every enemy and corpse is cleared away and each zone's spawns are placed again
+func (s *GameServer) adminReload() (string, error) {
+	s.clearEnemiesAndCorpses()
+
+	s.rooms = createZoneRooms()
+	for zoneID, room := range s.rooms {
+		s.broadcast <- types.Message{
+			Type: types.MsgRoomData,
+			Data: s.marshal(room),
+			Zone: zoneID,
+		}
+	}
+
+	s.spawnInitialEnemies()
+	return fmt.Sprintf("Reloaded %d zones with %d enemies", len(s.rooms), len(s.enemies)), nil
+}
+
+// splitTrailingPosition splits "<name> <x> <y>" into the name and position. Names
+// can contain spaces, so the position is only taken when both last words are numbers.
+func splitTrailingPosition(args string) (name string, x, y float64, ok bool) {
+	fields := strings.Fields(args)
+	if len(fields) < 3 {
+		return strings.TrimSpace(args), 0, 0, false
+	}
+
+	x, errX := strconv.ParseFloat(fields[len(fields)-2], 64)
+	y, errY := strconv.ParseFloat(fields[len(fields)-1], 64)
+	if errX != nil || errY != nil {
+		return strings.TrimSpace(args), 0, 0, false
+	}
+	return strings.Join(fields[:len(fields)-2], " "), x, y, true
+}
@@ -0,0 +1,144 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	rangeThreat     = 0.1 // Threat per tick for standing within an idle enemy's aggro radius
+	joinFightThreat = 1.0 // Threat an ally pulled into a fight starts with toward each player
+)
+
+// aiWorld lets the enemy state machine act on the server's live state.
+// It is only used while holding the server mutex.
+type aiWorld struct {
+	s   *GameServer
+	now time.Time
+}
+
+func (w aiWorld) Now() time.Time {
+	return w.now
+}
+
+func (w aiWorld) Rand() game.RNG {
+	return w.s.rng
+}
+
+func (w aiWorld) AcquireTarget(enemy *types.Enemy, radius float64) bool {
+	for _, player := range w.s.players {
+		if player.Dead || player.Zone != enemy.Zone {
+			continue
+		}
+
+		dx := player.X - enemy.X
+		dy := player.Y - enemy.Y
+		if math.Sqrt(dx*dx+dy*dy) <= radius {
+			enemy.ThreatList[player.ID] += rangeThreat
+		}
+	}
+
+	w.s.updateEnemyTarget(enemy)
+	return enemy.TargetID != ""
+}
+
+func (w aiWorld) Target(enemy *types.Enemy) (game.AITarget, bool) {
+	target, exists := w.s.players[enemy.TargetID]
+	if !exists || target.Dead {
+		enemy.TargetID = ""
+		w.s.updateEnemyTarget(enemy) // Try to find new target from threat list
+
+		if target, exists = w.s.players[enemy.TargetID]; !exists {
+			return game.AITarget{}, false
+		}
+	}
+
+	return game.AITarget{ID: target.ID, X: target.X, Y: target.Y}, true
+}
+
+func (w aiWorld) CanSee(enemy *types.Enemy, x, y float64) bool {
+	return w.s.hasLineOfSight(enemy.Zone, enemy.X, enemy.Y, x, y)
+}
+
+func (w aiWorld) MoveToward(enemy *types.Enemy, x, y, speed float64) bool {
+	return w.s.moveEnemyToward(enemy, x, y, speed)
+}
+
+func (w aiWorld) Attack(enemy *types.Enemy, target game.AITarget) {
+	if player, exists := w.s.players[target.ID]; exists {
+		w.s.attemptEnemyAttack(enemy, player)
+	}
+}
+
+func (w aiWorld) UseAbilities(enemy *types.Enemy, target game.AITarget, distance float64) {
+	w.s.processEncounter(enemy)
+
+	if player, exists := w.s.players[target.ID]; exists {
+		w.s.useEnemyAbilities(enemy, player, distance)
+	}
+}
+
+func (w aiWorld) CallForHelp(enemy *types.Enemy, radius float64) {
+	log.Printf("Enemy %s calls for help", enemy.Name)
+
+	for _, ally := range w.s.enemies {
+		dx := ally.X - enemy.X
+		dy := ally.Y - enemy.Y
+		if ally.ID != enemy.ID && ally.Zone == enemy.Zone && math.Sqrt(dx*dx+dy*dy) <= radius {
+			w.s.joinFight(ally, enemy)
+		}
+	}
+}
+
+func (w aiWorld) Evade(enemy *types.Enemy) {
+	log.Printf("Enemy %s is evading back to its spawn point", enemy.Name)
+
+	enemy.ThreatList = make(map[string]float64)
+	enemy.TargetID = ""
+	enemy.TauntedBy = ""
+	enemy.Auras = nil
+	w.s.refreshEnemyStats(enemy)
+	w.s.resetEncounter(enemy)
+}
+
+func (w aiWorld) FinishEvade(enemy *types.Enemy) {
+	enemy.Health = enemy.MaxHealth
+	log.Printf("Enemy %s has returned to its spawn point", enemy.Name)
+
+	w.s.broadcast <- types.Message{
+		Type: types.MsgEnemyUpdate,
+		Data: w.s.marshal(enemy),
+		Zone: enemy.Zone,
+	}
+}
+
+// pullPack brings every idle member of an enemy's pack into its fight
+func (s *GameServer) pullPack(enemy *types.Enemy) {
+	if enemy.PackID == "" {
+		return
+	}
+
+	for _, member := range s.enemies {
+		if member.ID != enemy.ID && member.PackID == enemy.PackID && member.Zone == enemy.Zone {
+			s.joinFight(member, enemy)
+		}
+	}
+}
+
+// joinFight puts an idle ally on the threat list of every player fighting the enemy.
+// Allies already fighting or evading are left alone.
+func (s *GameServer) joinFight(ally, enemy *types.Enemy) {
+	if ally.TargetID != "" || ally.AIState == types.AIEvade {
+		return
+	}
+
+	log.Printf("Enemy %s joins %s in its fight", ally.Name, enemy.Name)
+	for playerID := range enemy.ThreatList {
+		ally.ThreatList[playerID] += joinFightThreat
+	}
+	s.updateEnemyTarget(ally)
+}
@@ -0,0 +1,138 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	cleaveRageCost      = 20
+	cleaveBonusDamage   = 2
+	cleaveRadius        = 50.0
+	cleaveHalfAngle     = math.Pi / 4 // 90 degree cone in front of the warrior
+	cleaveMaxTargets    = 3
+	flamestrikeManaCost = 50
+	flamestrikeRange    = 300.0 // Pixels from the caster to the center of the circle
+	flamestrikeRadius   = 60.0
+	flamestrikeCooldown = 12 * time.Second
+	flamestrikeTargets  = 5
+)
+
+// enemiesInArea returns up to maxTargets living enemies inside an area of a zone, nearest
+// first. Enemies behind walls from the area's origin are not hit.
+func (s *GameServer) enemiesInArea(zone string, area game.Area, maxTargets int) []*types.Enemy {
+	var candidates []game.AreaTarget
+	for _, enemy := range s.enemies {
+		if enemy.Zone == zone && s.hasLineOfSight(zone, area.X, area.Y, enemy.X, enemy.Y) {
+			candidates = append(candidates, game.AreaTarget{ID: enemy.ID, X: enemy.X, Y: enemy.Y})
+		}
+	}
+
+	var enemies []*types.Enemy
+	for _, enemyID := range game.SelectAreaTargets(area, candidates, maxTargets) {
+		enemies = append(enemies, s.enemies[enemyID])
+	}
+	return enemies
+}
+
+// handleCleave strikes up to three enemies in a cone toward the given point
+func (s *GameServer) handleCleave(attacker *types.Player, towardX, towardY float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if attacker.Class != "warrior" {
+		log.Printf("Player %s (%s) attempted cleave but is not a warrior", attacker.Name, attacker.Class)
+		return
+	}
+	if attacker.Dead {
+		log.Printf("Player %s attempted cleave but is dead", attacker.Name)
+		return
+	}
+
+	if attacker.Mana < cleaveRageCost {
+		log.Printf("Player %s attempted cleave but lacks rage (%d/%d)", attacker.Name, attacker.Mana, cleaveRageCost)
+		return
+	}
+
+	area := game.ConeToward(attacker.X, attacker.Y, towardX, towardY, cleaveRadius, cleaveHalfAngle)
+	targets := s.enemiesInArea(attacker.Zone, area, cleaveMaxTargets)
+	if len(targets) == 0 {
+		log.Printf("Player %s attempted cleave but no enemies are in front of them", attacker.Name)
+		return
+	}
+
+	s.spendResource(attacker, cleaveRageCost)
+	log.Printf("Player %s used Cleave, hitting %d enemies", attacker.Name, len(targets))
+
+	for _, enemy := range targets {
+		damage := game.RollWeaponDamage(s.rng, attacker.Weapon, attacker.Derived.AttackPower) + cleaveBonusDamage
+		roll := game.RollMeleeAttack(s.rng, damage, attacker.Derived, enemy.Derived)
+		s.applyMeleeAbility(attacker, enemy, "cleave", roll)
+	}
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: attacker.ID,
+		Data:     s.marshal(attacker),
+	}
+}
+
+// handleFlamestrike burns up to five enemies in a circle on the ground
+func (s *GameServer) handleFlamestrike(caster *types.Player, x, y float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if caster.Class != "mage" {
+		log.Printf("Player %s (%s) attempted flamestrike but is not a mage", caster.Name, caster.Class)
+		return
+	}
+	if caster.Dead {
+		log.Printf("Player %s attempted flamestrike but is dead", caster.Name)
+		return
+	}
+
+	if s.isOnCooldown(caster, "flamestrike") {
+		log.Printf("Player %s attempted flamestrike but it is on cooldown", caster.Name)
+		return
+	}
+
+	if caster.Mana < flamestrikeManaCost {
+		log.Printf("Player %s attempted flamestrike but lacks mana (%d/%d)", caster.Name, caster.Mana, flamestrikeManaCost)
+		return
+	}
+
+	dx := x - caster.X
+	dy := y - caster.Y
+	if math.Sqrt(dx*dx+dy*dy) > flamestrikeRange {
+		log.Printf("Player %s attempted flamestrike at (%.0f, %.0f) but it is out of range", caster.Name, x, y)
+		return
+	}
+
+	if !s.hasLineOfSight(caster.Zone, caster.X, caster.Y, x, y) {
+		log.Printf("Player %s attempted flamestrike at (%.0f, %.0f) without line of sight", caster.Name, x, y)
+		return
+	}
+
+	s.spendResource(caster, flamestrikeManaCost)
+	s.startCooldown(caster, "flamestrike", flamestrikeCooldown)
+
+	area := game.Area{Shape: game.AreaCircle, X: x, Y: y, Radius: flamestrikeRadius}
+	targets := s.enemiesInArea(caster.Zone, area, flamestrikeTargets)
+	log.Printf("Player %s cast Flamestrike at (%.0f, %.0f), hitting %d enemies", caster.Name, x, y, len(targets))
+
+	for _, enemy := range targets {
+		damage := game.RollSpellDamage(s.rng, 12, 16, caster.Derived.SpellPower, 0.3)
+		roll := game.RollSpellAttack(s.rng, damage, caster.Derived, enemy.Derived)
+		s.applySpellDamage(caster, enemy, "flamestrike", roll)
+	}
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: caster.ID,
+		Data:     s.marshal(caster),
+	}
+}
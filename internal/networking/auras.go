@@ -0,0 +1,296 @@
+package networking
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	dispelMagicManaCost = 25
+	dispelMagicRange    = 300.0 // Pixels
+	dispelMagicCooldown = 8 * time.Second
+	dispelMagicThreat   = 20 // Flat threat for stripping an enemy's buff
+)
+
+func (s *GameServer) handleAuras() {
+	ticker := time.NewTicker(250 * time.Millisecond) // Check auras 4 times per second
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mutex.Lock()
+
+		now := time.Now()
+		for _, player := range s.players {
+			s.processPlayerAuras(player, now)
+		}
+		for _, enemy := range s.enemies {
+			s.processEnemyAuras(enemy, now)
+		}
+
+		s.mutex.Unlock()
+	}
+}
+
+// processPlayerAuras applies periodic ticks and removes expired auras on a player
+func (s *GameServer) processPlayerAuras(player *types.Player, now time.Time) {
+	if player.Dead || len(player.Auras) == 0 {
+		return
+	}
+
+	changed := false
+	for _, aura := range player.Auras {
+		damage, heal := game.TickAura(aura, now)
+		if damage == 0 && heal == 0 {
+			continue
+		}
+
+		effectiveHeal := min(heal, player.MaxHealth-player.Health+damage)
+		player.Health = min(player.MaxHealth, player.Health-damage+heal)
+		changed = true
+		s.broadcastAuraTick(aura, player.ID, damage, heal)
+
+		if healer, exists := s.players[aura.SourceID]; exists && !healer.Dead {
+			s.addHealingThreat(healer, player, aura.ID, effectiveHeal)
+		}
+
+		log.Printf("%s ticked on player %s for %d damage and %d healing (HP: %d/%d)",
+			aura.Name, player.Name, damage, heal, player.Health, player.MaxHealth)
+
+		if player.Health <= 0 {
+			log.Printf("Player %s has been defeated by %s", player.Name, aura.Name)
+			s.killPlayer(player, aura.SourceID)
+			return
+		}
+	}
+
+	var expired []*types.Aura
+	player.Auras, expired = game.ExpireAuras(player.Auras, now)
+	if len(expired) > 0 {
+		s.refreshPlayerStats(player)
+		changed = true
+	}
+
+	if changed {
+		s.broadcast <- types.Message{
+			Type:     types.MsgPlayerUpdate,
+			PlayerID: player.ID,
+			Data:     s.marshal(player),
+		}
+	}
+}
+
+// processEnemyAuras applies periodic ticks and removes expired auras on an enemy.
+// Periodic damage generates threat for the player who applied the aura.
+func (s *GameServer) processEnemyAuras(enemy *types.Enemy, now time.Time) {
+	if len(enemy.Auras) == 0 {
+		return
+	}
+
+	changed := false
+	for _, aura := range enemy.Auras {
+		damage, heal := game.TickAura(aura, now)
+		if damage == 0 && heal == 0 {
+			continue
+		}
+
+		enemy.Health = min(enemy.MaxHealth, enemy.Health-damage+heal)
+		changed = true
+		s.broadcastAuraTick(aura, enemy.ID, damage, heal)
+
+		if source, exists := s.players[aura.SourceID]; exists && !source.Dead && damage > 0 {
+			s.addThreat(enemy, source.ID, aura.ID, float64(damage))
+		}
+
+		log.Printf("%s ticked on %s for %d damage and %d healing (HP: %d/%d)",
+			aura.Name, enemy.Name, damage, heal, enemy.Health, enemy.MaxHealth)
+
+		if enemy.Health <= 0 {
+			log.Printf("Enemy %s has been defeated by %s", enemy.Name, aura.Name)
+			s.killEnemy(enemy, aura.SourceID)
+			return
+		}
+	}
+
+	var expired []*types.Aura
+	enemy.Auras, expired = game.ExpireAuras(enemy.Auras, now)
+	if len(expired) > 0 {
+		s.refreshEnemyStats(enemy)
+		changed = true
+	}
+
+	if changed {
+		s.broadcast <- types.Message{
+			Type: types.MsgEnemyUpdate,
+			Data: s.marshal(enemy),
+			Zone: enemy.Zone,
+		}
+	}
+}
+
+// broadcastAuraTick reports the damage and healing from a periodic aura tick
+func (s *GameServer) broadcastAuraTick(aura *types.Aura, targetID string, damage, heal int) {
+	if damage > 0 {
+		s.broadcastCombatEvent(types.CombatEvent{
+			Kind:     types.CombatDamage,
+			SourceID: aura.SourceID,
+			TargetID: targetID,
+			Ability:  aura.ID,
+			HitType:  types.HitNormal,
+			Amount:   damage,
+		})
+	}
+	if heal > 0 {
+		s.broadcastCombatEvent(types.CombatEvent{
+			Kind:     types.CombatHeal,
+			SourceID: aura.SourceID,
+			TargetID: targetID,
+			Ability:  aura.ID,
+			HitType:  types.HitNormal,
+			Amount:   heal,
+		})
+	}
+}
+
+func (s *GameServer) handleRend(attacker *types.Player, targetEnemyID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if attacker.Class != "warrior" {
+		log.Printf("Player %s (%s) attempted rend but is not a warrior", attacker.Name, attacker.Class)
+		return
+	}
+	if attacker.Dead {
+		log.Printf("Player %s attempted rend but is dead", attacker.Name)
+		return
+	}
+
+	rageCost := 10
+	if attacker.Mana < rageCost {
+		log.Printf("Player %s attempted rend but lacks rage (%d/%d)", attacker.Name, attacker.Mana, rageCost)
+		return
+	}
+
+	enemy, exists := s.zoneEnemy(attacker, targetEnemyID)
+	if !exists {
+		log.Printf("Rend: Enemy %s not found", targetEnemyID)
+		return
+	}
+
+	aura, _ := game.NewAura("rend", attacker.ID, time.Now())
+
+	s.spendResource(attacker, rageCost)
+	enemy.Auras = game.ApplyAura(enemy.Auras, aura)
+	s.refreshEnemyStats(enemy)
+	log.Printf("Player %s applied Rend to %s", attacker.Name, enemy.Name)
+
+	s.addThreat(enemy, attacker.ID, "rend", 5) // Small flat threat for applying the bleed
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: attacker.ID,
+		Data:     s.marshal(attacker),
+	}
+	s.broadcast <- types.Message{
+		Type: types.MsgEnemyUpdate,
+		Data: s.marshal(enemy),
+		Zone: enemy.Zone,
+	}
+}
+
+// handleDispelMagic strips one beneficial magic aura, such as a boss's Frenzy, from an enemy
+func (s *GameServer) handleDispelMagic(caster *types.Player, targetEnemyID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if caster.Class != "priest" {
+		log.Printf("Player %s (%s) attempted dispel magic but is not a priest", caster.Name, caster.Class)
+		return
+	}
+	if caster.Dead {
+		log.Printf("Player %s attempted dispel magic but is dead", caster.Name)
+		return
+	}
+
+	if s.isOnCooldown(caster, "dispel_magic") {
+		log.Printf("Player %s attempted dispel magic but it is on cooldown", caster.Name)
+		return
+	}
+	if caster.Mana < dispelMagicManaCost {
+		log.Printf("Player %s attempted dispel magic but lacks mana (%d/%d)", caster.Name, caster.Mana, dispelMagicManaCost)
+		return
+	}
+
+	enemy, exists := s.zoneEnemy(caster, targetEnemyID)
+	if !exists {
+		log.Printf("Dispel Magic: Enemy %s not found", targetEnemyID)
+		return
+	}
+
+	if math.Hypot(enemy.X-caster.X, enemy.Y-caster.Y) > dispelMagicRange {
+		log.Printf("Player %s attempted dispel magic on %s but is out of range", caster.Name, enemy.Name)
+		return
+	}
+
+	remaining, dispelled := game.DispelAuras(enemy.Auras, types.DispelMagic, false, 1)
+	if len(dispelled) == 0 {
+		s.sendError(caster, fmt.Sprintf("%s has nothing to dispel", enemy.Name))
+		return
+	}
+
+	s.spendResource(caster, dispelMagicManaCost)
+	s.startCooldown(caster, "dispel_magic", dispelMagicCooldown)
+	enemy.Auras = remaining
+	s.refreshEnemyStats(enemy)
+	s.addThreat(enemy, caster.ID, "dispel_magic", dispelMagicThreat)
+	log.Printf("Player %s dispelled %s from %s", caster.Name, dispelled[0].Name, enemy.Name)
+	s.sendNotice(caster, fmt.Sprintf("You dispel %s from %s", dispelled[0].Name, enemy.Name))
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: caster.ID,
+		Data:     s.marshal(caster),
+	}
+	s.broadcast <- types.Message{
+		Type: types.MsgEnemyUpdate,
+		Data: s.marshal(enemy),
+		Zone: enemy.Zone,
+	}
+}
+
+func (s *GameServer) handleBattleShout(player *types.Player) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if player.Class != "warrior" {
+		log.Printf("Player %s (%s) attempted battle shout but is not a warrior", player.Name, player.Class)
+		return
+	}
+	if player.Dead {
+		log.Printf("Player %s attempted battle shout but is dead", player.Name)
+		return
+	}
+
+	rageCost := 10
+	if player.Mana < rageCost {
+		log.Printf("Player %s attempted battle shout but lacks rage (%d/%d)", player.Name, player.Mana, rageCost)
+		return
+	}
+
+	aura, _ := game.NewAura("battle_shout", player.ID, time.Now())
+
+	s.spendResource(player, rageCost)
+	player.Auras = game.ApplyAura(player.Auras, aura)
+	s.refreshPlayerStats(player)
+	log.Printf("Player %s used Battle Shout", player.Name)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: player.ID,
+		Data:     s.marshal(player),
+	}
+}
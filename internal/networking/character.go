@@ -0,0 +1,113 @@
+package networking
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	maxNameLength       = 16              // In bytes; longer names are cut short
+	characterCreateWait = 5 * time.Minute // How long a connection may sit on the creation screen
+)
+
+// readCharacterCreation waits for the client's character creation choices
+func (s *GameServer) readCharacterCreation(conn *websocket.Conn) (class, name, token string, err error) {
+	conn.SetReadDeadline(time.Now().Add(characterCreateWait))
+	defer conn.SetReadDeadline(time.Time{})
+
+	var msg types.Message
+	if err := conn.ReadJSON(&msg); err != nil {
+		return "", "", "", err
+	}
+
+	if msg.Type != types.MsgCharacterCreate {
+		return "", "", "", fmt.Errorf("expected %s message, got %s", types.MsgCharacterCreate, msg.Type)
+	}
+
+	var createData struct {
+		Class string `json:"class"`
+		Name  string `json:"name"`
+		Token string `json:"token,omitempty"` // Staff login token, see staffRole
+	}
+	if err := json.Unmarshal(msg.Data, &createData); err != nil {
+		return "", "", "", fmt.Errorf("invalid character data: %w", err)
+	}
+
+	if _, exists := game.LookupClass(createData.Class); !exists {
+		return "", "", "", fmt.Errorf("unknown class %q", createData.Class)
+	}
+
+	return createData.Class, cleanText(createData.Name, maxNameLength), createData.Token, nil
+}
+
+// addPlayer puts a newly created character into the starting zone. Names must be
+// unique among online players so whispers and staff commands reach the right one;
+// false is returned when the name is taken.
+func (s *GameServer) addPlayer(player *types.Player) (isFirstPlayer, added bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, other := range s.players {
+		if strings.EqualFold(other.Name, player.Name) {
+			return false, false
+		}
+	}
+
+	entry := s.rooms[game.StartingZone].Entry
+	player.Zone = game.StartingZone
+	player.X = entry.X
+	player.Y = entry.Y
+	s.players[player.ID] = player
+	return len(s.players) == 1, true
+}
+
+// newPlayer creates a character of the given class with its starting stats and gear
+func (s *GameServer) newPlayer(conn *websocket.Conn, class, name string) *types.Player {
+	definition, _ := game.LookupClass(class)
+
+	playerID := uuid.New().String()
+	if name == "" {
+		name = "Player " + playerID[:8]
+	}
+
+	player := &types.Player{
+		ID:        playerID,
+		Name:      name,
+		Class:     class,
+		Level:     1,
+		Resource:  definition.Resource.Type,
+		Strength:  definition.BaseStats.Strength,
+		Agility:   definition.BaseStats.Agility,
+		Intellect: definition.BaseStats.Intellect,
+		Stamina:   definition.BaseStats.Stamina,
+		Armor:     definition.BaseStats.Armor,
+		Conn:      conn,
+		Cooldowns: make(map[string]int64),
+		Bag:       game.NewBag(),
+		Equipment: make(map[types.EquipSlot]string),
+	}
+	for _, itemID := range definition.StartingGear {
+		if item, exists := game.LookupItem(itemID); exists && item.Slot != "" {
+			player.Equipment[item.Slot] = itemID
+		}
+	}
+	for _, item := range game.StartingItems {
+		game.AddToBag(player.Bag, item)
+	}
+	player.Weapon = game.EquippedWeapon(player)
+	s.refreshPlayerStats(player)
+	player.Health = player.MaxHealth
+
+	if definition.Resource.StartsFull {
+		player.Mana = player.MaxMana
+	}
+
+	return player
+}
@@ -0,0 +1,162 @@
+package networking
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	maxChatLength = 255 // Longest chat message in bytes; longer ones are cut short
+
+	sayRange  = 400.0  // Pixels a say message carries
+	yellRange = 1200.0 // Pixels a yell carries
+
+	chatBurst      = 5               // Messages a player can send back to back
+	chatRefillTime = 2 * time.Second // Time to earn back one message of the burst
+)
+
+// chatAllowance is a player's rate limit bucket: they can send a message while they have a token
+type chatAllowance struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take spends a token if one is available, topping the bucket up for the time since it was last used
+func (a *chatAllowance) take(now time.Time) bool {
+	a.tokens = math.Min(chatBurst, a.tokens+now.Sub(a.updated).Seconds()/chatRefillTime.Seconds())
+	a.updated = now
+	if a.tokens < 1 {
+		return false
+	}
+	a.tokens--
+	return true
+}
+
+// handleChat delivers a chat message to everyone on its channel
+func (s *GameServer) handleChat(player *types.Player, chat types.ChatMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	text := cleanChatText(chat.Text)
+	if text == "" {
+		return
+	}
+
+	allowance, exists := s.chatAllowances[player.ID]
+	if !exists {
+		allowance = &chatAllowance{tokens: chatBurst, updated: time.Now()}
+		s.chatAllowances[player.ID] = allowance
+	}
+	if !allowance.take(time.Now()) {
+		s.sendError(player, "you are sending messages too quickly")
+		return
+	}
+
+	var recipients []string
+	target := ""
+	switch chat.Channel {
+	case types.ChatSay:
+		recipients = s.playersInRange(player, sayRange)
+	case types.ChatYell:
+		recipients = s.playersInRange(player, yellRange)
+	case types.ChatParty:
+		if player.PartyID == "" {
+			s.sendError(player, "you are not in a party")
+			return
+		}
+		for _, member := range s.partyMembers(player) {
+			recipients = append(recipients, member.ID)
+		}
+	case types.ChatWhisper:
+		recipient, err := s.findPlayer(chat.Target)
+		if err != nil {
+			s.sendError(player, err.Error())
+			return
+		}
+		if recipient.ID == player.ID {
+			s.sendError(player, "you cannot whisper to yourself")
+			return
+		}
+		// The sender gets a copy so they can see what they whispered
+		recipients = []string{recipient.ID, player.ID}
+		target = recipient.Name
+	case types.ChatGlobal:
+		recipients = nil // Everyone
+	default:
+		log.Printf("Player %s attempted to chat on unknown channel %q", player.Name, chat.Channel)
+		return
+	}
+
+	if target != "" {
+		log.Printf("[%s] %s to %s: %s", chat.Channel, player.Name, target, text)
+	} else {
+		log.Printf("[%s] %s: %s", chat.Channel, player.Name, text)
+	}
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgChat,
+		PlayerID: player.ID,
+		Data: s.marshal(types.ChatMessage{
+			Channel:    chat.Channel,
+			Target:     target,
+			SenderID:   player.ID,
+			SenderName: player.Name,
+			Text:       text,
+		}),
+		Recipients: recipients,
+	}
+}
+
+// cleanChatText trims a chat message, strips control characters and cuts it to the length limit
+func cleanChatText(text string) string {
+	return cleanText(text, maxChatLength)
+}
+
+// cleanText strips control characters from text players typed, trims it and cuts
+// it to at most maxLength bytes without splitting a character
+func cleanText(text string, maxLength int) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+	text = strings.TrimSpace(text)
+
+	if len(text) > maxLength {
+		text = strings.TrimSpace(strings.ToValidUTF8(text[:maxLength], ""))
+	}
+	return text
+}
+
+// playersInRange returns the IDs of every player in the speaker's zone within a distance of them, the speaker included
+func (s *GameServer) playersInRange(speaker *types.Player, distance float64) []string {
+	var ids []string
+	for _, player := range s.players {
+		if player.Zone == speaker.Zone && math.Hypot(player.X-speaker.X, player.Y-speaker.Y) <= distance {
+			ids = append(ids, player.ID)
+		}
+	}
+	return ids
+}
+
+// findPlayer looks up an online player by ID, or by name ignoring case. Names are
+// unique ignoring case, so at most one player can match.
+func (s *GameServer) findPlayer(idOrName string) (*types.Player, error) {
+	if player, exists := s.players[idOrName]; exists {
+		return player, nil
+	}
+
+	for _, player := range s.players {
+		if strings.EqualFold(player.Name, idOrName) {
+			return player, nil
+		}
+	}
+	return nil, fmt.Errorf("no player named %q is online", idOrName)
+}
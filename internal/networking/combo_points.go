@@ -0,0 +1,139 @@
+package networking
+
+import (
+	"log"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	sinisterStrikeEnergyCost = 40
+	sinisterStrikeBonus      = 3 // Flat damage added to the weapon swing
+	eviscerateEnergyCost     = 35
+	eviscerateBaseDamage     = 6
+	eviscerateDamagePerPoint = 8
+)
+
+// handleSinisterStrike is the rogue's combo point generator
+func (s *GameServer) handleSinisterStrike(attacker *types.Player, targetEnemyID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if attacker.Class != "rogue" {
+		log.Printf("Player %s (%s) attempted sinister strike but is not a rogue", attacker.Name, attacker.Class)
+		return
+	}
+	if attacker.Dead {
+		log.Printf("Player %s attempted sinister strike but is dead", attacker.Name)
+		return
+	}
+
+	if attacker.Mana < sinisterStrikeEnergyCost {
+		log.Printf("Player %s attempted sinister strike but lacks energy (%d/%d)", attacker.Name, attacker.Mana, sinisterStrikeEnergyCost)
+		return
+	}
+
+	enemy, exists := s.zoneEnemy(attacker, targetEnemyID)
+	if !exists {
+		log.Printf("Sinister Strike: Enemy %s not found", targetEnemyID)
+		return
+	}
+
+	s.spendResource(attacker, sinisterStrikeEnergyCost)
+
+	damage := game.RollWeaponDamage(s.rng, attacker.Weapon, attacker.Derived.AttackPower) + sinisterStrikeBonus
+	roll := game.RollMeleeAttack(s.rng, damage, attacker.Derived, enemy.Derived)
+
+	// Combo points are only awarded when the strike connects
+	if roll.Landed() {
+		game.AddComboPoints(attacker, enemy.ID, 1)
+	}
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: attacker.ID,
+		Data:     s.marshal(attacker),
+	}
+
+	s.applyMeleeAbility(attacker, enemy, "sinister_strike", roll)
+}
+
+// handleEviscerate is the rogue's finisher, spending every combo point on the target
+func (s *GameServer) handleEviscerate(attacker *types.Player, targetEnemyID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if attacker.Class != "rogue" {
+		log.Printf("Player %s (%s) attempted eviscerate but is not a rogue", attacker.Name, attacker.Class)
+		return
+	}
+	if attacker.Dead {
+		log.Printf("Player %s attempted eviscerate but is dead", attacker.Name)
+		return
+	}
+
+	if attacker.Mana < eviscerateEnergyCost {
+		log.Printf("Player %s attempted eviscerate but lacks energy (%d/%d)", attacker.Name, attacker.Mana, eviscerateEnergyCost)
+		return
+	}
+
+	enemy, exists := s.zoneEnemy(attacker, targetEnemyID)
+	if !exists {
+		log.Printf("Eviscerate: Enemy %s not found", targetEnemyID)
+		return
+	}
+
+	if attacker.ComboTargetID != enemy.ID || attacker.ComboPoints == 0 {
+		log.Printf("Player %s attempted eviscerate on %s without combo points", attacker.Name, enemy.Name)
+		return
+	}
+
+	s.spendResource(attacker, eviscerateEnergyCost)
+	points := game.SpendComboPoints(attacker, enemy.ID)
+
+	weaponDelay := time.Second
+	if attacker.Weapon != nil {
+		weaponDelay = attacker.Weapon.Delay
+	}
+
+	damage := eviscerateBaseDamage + eviscerateDamagePerPoint*points + game.AttackPowerBonus(attacker.Derived.AttackPower, weaponDelay)
+	roll := game.RollMeleeAttack(s.rng, damage, attacker.Derived, enemy.Derived)
+	log.Printf("Player %s used Eviscerate with %d combo points", attacker.Name, points)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: attacker.ID,
+		Data:     s.marshal(attacker),
+	}
+
+	s.applyMeleeAbility(attacker, enemy, "eviscerate", roll)
+}
+
+// applyMeleeAbility deals a rolled melee ability hit to an enemy, generating threat for the attacker
+func (s *GameServer) applyMeleeAbility(attacker *types.Player, enemy *types.Enemy, ability string, roll game.AttackRoll) {
+	s.broadcastAttackEvent(attacker.ID, enemy.ID, ability, roll)
+
+	if !roll.Landed() {
+		log.Printf("Player %s used %s on %s but it was a %s", attacker.Name, ability, enemy.Name, roll.HitType)
+		return
+	}
+
+	enemy.Health -= roll.Damage
+	log.Printf("Player %s used %s on %s for %d damage (%s, %d mitigated) (HP: %d/%d)",
+		attacker.Name, ability, enemy.Name, roll.Damage, roll.HitType, roll.Mitigated, enemy.Health, enemy.MaxHealth)
+
+	s.addThreat(enemy, attacker.ID, ability, float64(roll.Damage))
+
+	if enemy.Health <= 0 {
+		log.Printf("Enemy %s has been defeated by %s's %s", enemy.Name, attacker.Name, ability)
+		s.killEnemy(enemy, attacker.ID)
+	} else {
+		s.broadcast <- types.Message{
+			Type: types.MsgEnemyUpdate,
+			Data: s.marshal(enemy),
+			Zone: enemy.Zone,
+		}
+	}
+}
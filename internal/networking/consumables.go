@@ -0,0 +1,71 @@
+package networking
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// handleUseItem drinks or eats one of a consumable from the player's bag
+func (s *GameServer) handleUseItem(player *types.Player, itemID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if player.Dead {
+		log.Printf("Player %s attempted to use %s but is dead", player.Name, itemID)
+		return
+	}
+
+	definition, exists := game.LookupItem(itemID)
+	if !exists || definition.Use == nil {
+		log.Printf("Player %s attempted to use %s, which is not usable", player.Name, itemID)
+		s.sendError(player, fmt.Sprintf("%s cannot be used", game.ItemName(itemID)))
+		return
+	}
+
+	if game.CountInBag(player.Bag, itemID) == 0 {
+		log.Printf("Player %s attempted to use %s but has none", player.Name, definition.Name)
+		s.sendError(player, fmt.Sprintf("you have no %s", definition.Name))
+		return
+	}
+
+	cooldownKey := game.ItemCooldownKey(itemID)
+	if s.isOnCooldown(player, cooldownKey) {
+		log.Printf("Player %s attempted to use %s but it is on cooldown", player.Name, definition.Name)
+		s.sendError(player, "that item is not ready yet")
+		return
+	}
+
+	use := definition.Use
+	if use.Resource != "" && use.Resource != player.Resource {
+		log.Printf("Player %s (%s) attempted to use %s but does not use %s", player.Name, player.Class, definition.Name, use.Resource)
+		s.sendError(player, fmt.Sprintf("you do not use %s", use.Resource))
+		return
+	}
+
+	game.TakeOneFromBag(player.Bag, itemID)
+	s.startCooldown(player, cooldownKey, use.Cooldown)
+	log.Printf("Player %s used %s", player.Name, definition.Name)
+
+	if use.MaxHeal > 0 {
+		amount := game.RollSpellDamage(s.rng, use.MinHeal, use.MaxHeal, 0, 0)
+		s.applyHeal(player, player, itemID, game.AttackRoll{HitType: types.HitNormal, Damage: amount})
+	}
+
+	if use.MaxRestore > 0 {
+		amount := game.RollSpellDamage(s.rng, use.MinRestore, use.MaxRestore, 0, 0)
+		previous := player.Mana
+		game.GainResource(player, amount)
+		log.Printf("Player %s restored %d %s (%d/%d)", player.Name, player.Mana-previous, player.Resource, player.Mana, player.MaxMana)
+	}
+
+	if aura, exists := game.NewAura(use.AuraID, player.ID, time.Now()); exists {
+		player.Auras = game.ApplyAura(player.Auras, aura)
+		s.refreshPlayerStats(player)
+	}
+
+	s.broadcastPlayerUpdate(player)
+}
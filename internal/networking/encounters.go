@@ -0,0 +1,138 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const addSpawnDistance = 60.0 // Pixels from the boss that summoned adds appear
+
+// processEncounter advances a boss in combat through its phases and enrage timer
+func (s *GameServer) processEncounter(boss *types.Enemy) {
+	script := game.EnemyEncounter(boss)
+	if script == nil {
+		return
+	}
+
+	now := time.Now()
+	if boss.EngagedAt.IsZero() {
+		boss.EngagedAt = now
+		log.Printf("Boss %s engaged", boss.Name)
+		s.enemyYell(boss, script.Phases[0].Yell)
+	}
+
+	healthPct := float64(boss.Health) / float64(boss.MaxHealth)
+	nextPhase := game.EncounterPhaseAt(script, boss.Phase, healthPct)
+	for boss.Phase < nextPhase {
+		boss.Phase++
+		s.enterEncounterPhase(boss, script.Phases[boss.Phase])
+	}
+
+	if !boss.Enraged && game.ShouldEnrage(script, boss.EngagedAt, now) {
+		boss.Enraged = true
+		if aura, exists := game.NewAura(script.EnrageAura, boss.ID, now); exists {
+			boss.Auras = game.ApplyAura(boss.Auras, aura)
+			s.refreshEnemyStats(boss)
+		}
+		log.Printf("Boss %s has enraged", boss.Name)
+		s.enemyYell(boss, script.EnrageYell)
+
+		s.broadcast <- types.Message{
+			Type: types.MsgEnemyUpdate,
+			Data: s.marshal(boss),
+			Zone: boss.Zone,
+		}
+	}
+}
+
+// enterEncounterPhase announces a new phase and summons its adds
+func (s *GameServer) enterEncounterPhase(boss *types.Enemy, phase game.EncounterPhase) {
+	log.Printf("Boss %s entered phase %d (%s)", boss.Name, boss.Phase+1, phase.Name)
+	s.enemyYell(boss, phase.Yell)
+
+	for _, spawn := range phase.Adds {
+		for i := 0; i < spawn.Count; i++ {
+			angle := 2 * math.Pi * float64(i) / float64(spawn.Count)
+			s.summonAdd(boss, spawn.EnemyType, boss.X+math.Cos(angle)*addSpawnDistance, boss.Y+math.Sin(angle)*addSpawnDistance)
+		}
+	}
+
+	s.broadcast <- types.Message{
+		Type: types.MsgEnemyUpdate,
+		Data: s.marshal(boss),
+		Zone: boss.Zone,
+	}
+}
+
+// summonAdd spawns an enemy beside a boss that joins the fight against everyone
+// on the boss's threat list
+func (s *GameServer) summonAdd(boss *types.Enemy, enemyType string, x, y float64) {
+	if game.CheckWallCollision(x, y, s.rooms[boss.Zone].Walls) {
+		x, y = boss.X, boss.Y
+	}
+
+	template, _ := game.LookupEnemyTemplate(enemyType)
+	add := s.spawnEnemy(boss.Zone, game.EnemySpawn{EnemyType: enemyType, Name: template.Name, X: x, Y: y})
+	add.SummonerID = boss.ID
+	s.joinFight(add, boss)
+}
+
+// resetEncounter returns a boss to full health and its first phase when it
+// evades, and removes the adds it summoned and the ground effects it has yet to land
+func (s *GameServer) resetEncounter(boss *types.Enemy) {
+	script := game.EnemyEncounter(boss)
+	if script == nil {
+		return
+	}
+
+	for _, enemy := range s.enemies {
+		if enemy.SummonerID == boss.ID {
+			s.despawnEnemy(enemy)
+		}
+	}
+	for effectID, effect := range s.groundEffects {
+		if effect.SourceID == boss.ID {
+			delete(s.groundEffects, effectID)
+		}
+	}
+
+	boss.EngagedAt = time.Time{}
+	boss.Phase = 0
+	boss.Enraged = false
+	boss.Auras = nil
+	boss.Cooldowns = make(map[string]time.Time)
+	s.refreshEnemyStats(boss)
+	boss.Health = boss.MaxHealth
+
+	log.Printf("Boss %s has reset", boss.Name)
+	s.enemyYell(boss, script.ResetYell)
+
+	s.broadcast <- types.Message{
+		Type: types.MsgEnemyUpdate,
+		Data: s.marshal(boss),
+		Zone: boss.Zone,
+	}
+}
+
+// enemyYell broadcasts a line spoken by an enemy
+func (s *GameServer) enemyYell(enemy *types.Enemy, text string) {
+	if text == "" {
+		return
+	}
+
+	log.Printf("%s yells: %s", enemy.Name, text)
+
+	s.broadcast <- types.Message{
+		Type: types.MsgEnemyYell,
+		Data: s.marshal(types.EnemyYell{
+			EnemyID: enemy.ID,
+			Name:    enemy.Name,
+			Text:    text,
+		}),
+		Zone: enemy.Zone,
+	}
+}
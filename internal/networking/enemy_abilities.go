@@ -0,0 +1,189 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/google/uuid"
+)
+
+const enemyAbilityInterval = time.Second // How often an enemy considers using a special ability
+
+// groundEffect is a telegraphed area waiting to land, along with the server-only
+// details clients don't need
+type groundEffect struct {
+	types.GroundEffect
+	source    *types.Enemy
+	landsAt   time.Time
+	minDamage int
+	maxDamage int
+}
+
+// useEnemyAbilities lets an enemy in combat use the first special ability its
+// template allows, at most once per enemyAbilityInterval
+func (s *GameServer) useEnemyAbilities(enemy *types.Enemy, target *types.Player, distance float64) {
+	now := time.Now()
+	if target.Dead || now.Before(enemy.NextAbilityCheck) {
+		return
+	}
+	enemy.NextAbilityCheck = now.Add(enemyAbilityInterval)
+
+	context := game.EnemyAbilityContext{
+		HealthPct:      float64(enemy.Health) / float64(enemy.MaxHealth),
+		TargetDistance: distance,
+		Reach:          game.WeaponReach(enemy.Weapon),
+		LineOfSight:    s.hasLineOfSight(enemy.Zone, enemy.X, enemy.Y, target.X, target.Y),
+	}
+
+	ability, ok := game.ChooseEnemyAbility(s.rng, game.EnemyAbilities(enemy), enemy.Cooldowns, context, now)
+	if !ok {
+		return
+	}
+	enemy.Cooldowns[ability.ID] = now.Add(ability.Cooldown)
+
+	switch ability.Kind {
+	case game.EnemyStrike:
+		s.enemyStrike(enemy, target, ability)
+	case game.EnemyGroundAoE:
+		s.placeGroundEffect(enemy, s.groundEffectTarget(enemy, target, ability), ability)
+	case game.EnemyHealSelf:
+		s.enemyHealSelf(enemy, ability)
+	case game.EnemyBuffSelf:
+		s.enemyBuffSelf(enemy, ability)
+	}
+}
+
+// enemyStrike attacks the target with the enemy's weapon plus the ability's bonus damage
+func (s *GameServer) enemyStrike(enemy *types.Enemy, target *types.Player, ability game.EnemyAbility) {
+	damage := game.RollWeaponDamage(s.rng, enemy.Weapon, enemy.Derived.AttackPower) +
+		game.RollSpellDamage(s.rng, ability.MinDamage, ability.MaxDamage, 0, 0)
+	log.Printf("Enemy %s used %s on %s", enemy.Name, ability.Name, target.Name)
+
+	if game.IsRangedWeapon(enemy.Weapon) {
+		roll := game.RollAutoAttack(s.rng, enemy.Weapon, damage, enemy.Derived, target.Derived)
+		s.launchProjectile(enemy.ID, target.ID, ability.ID, "arrow", game.ProjectileSpeed(enemy.Weapon), roll)
+		return
+	}
+
+	roll := game.RollMeleeAttack(s.rng, damage, enemy.Derived, target.Derived)
+	s.applyEnemyDamage(enemy, target, ability.ID, roll)
+}
+
+// groundEffectTarget picks who a ground effect lands under: a random living player on
+// the threat list within range if the ability asks for one, otherwise the current target
+func (s *GameServer) groundEffectTarget(enemy *types.Enemy, target *types.Player, ability game.EnemyAbility) *types.Player {
+	if !ability.RandomTarget {
+		return target
+	}
+
+	var candidates []*types.Player
+	for playerID := range enemy.ThreatList {
+		player, exists := s.players[playerID]
+		if !exists || player.Dead {
+			continue
+		}
+
+		dx := player.X - enemy.X
+		dy := player.Y - enemy.Y
+		if ability.Conditions.MaxRange > 0 && math.Sqrt(dx*dx+dy*dy) > ability.Conditions.MaxRange {
+			continue
+		}
+		candidates = append(candidates, player)
+	}
+
+	if len(candidates) == 0 {
+		return target
+	}
+	return candidates[s.rng.Intn(len(candidates))]
+}
+
+// placeGroundEffect telegraphs a circle under the target that lands after the ability's delay
+func (s *GameServer) placeGroundEffect(enemy *types.Enemy, target *types.Player, ability game.EnemyAbility) {
+	effect := &groundEffect{
+		GroundEffect: types.GroundEffect{
+			ID:       uuid.New().String(),
+			SourceID: enemy.ID,
+			Ability:  ability.ID,
+			X:        target.X,
+			Y:        target.Y,
+			Radius:   ability.Radius,
+			Warning:  ability.Delay,
+		},
+		source:    enemy,
+		landsAt:   time.Now().Add(ability.Delay),
+		minDamage: ability.MinDamage,
+		maxDamage: ability.MaxDamage,
+	}
+	s.groundEffects[effect.ID] = effect
+	log.Printf("Enemy %s began %s at (%.0f, %.0f)", enemy.Name, ability.Name, effect.X, effect.Y)
+
+	s.broadcast <- types.Message{
+		Type: types.MsgGroundEffect,
+		Data: s.marshal(effect.GroundEffect),
+		Zone: enemy.Zone,
+	}
+}
+
+// landGroundEffects damages every living player standing in a ground effect whose
+// warning has run out. Effects still land if their source has died since.
+func (s *GameServer) landGroundEffects(now time.Time) {
+	for effectID, effect := range s.groundEffects {
+		if now.Before(effect.landsAt) {
+			continue
+		}
+		delete(s.groundEffects, effectID)
+
+		area := game.Area{Shape: game.AreaCircle, X: effect.X, Y: effect.Y, Radius: effect.Radius}
+		for _, player := range s.players {
+			if player.Dead || player.Zone != effect.source.Zone || !area.Contains(player.X, player.Y) {
+				continue
+			}
+
+			damage := game.RollSpellDamage(s.rng, effect.minDamage, effect.maxDamage, 0, 0)
+			s.applyEnemyDamage(effect.source, player, effect.Ability, game.AttackRoll{HitType: types.HitNormal, Damage: damage})
+		}
+	}
+}
+
+// enemyHealSelf restores a share of the enemy's max health
+func (s *GameServer) enemyHealSelf(enemy *types.Enemy, ability game.EnemyAbility) {
+	amount := max(1, int(math.Round(float64(enemy.MaxHealth)*ability.HealPct)))
+	enemy.Health = min(enemy.MaxHealth, enemy.Health+amount)
+	log.Printf("Enemy %s used %s, healing for %d (HP: %d/%d)", enemy.Name, ability.Name, amount, enemy.Health, enemy.MaxHealth)
+
+	s.broadcastCombatEvent(types.CombatEvent{
+		Kind:     types.CombatHeal,
+		SourceID: enemy.ID,
+		TargetID: enemy.ID,
+		Ability:  ability.ID,
+		HitType:  types.HitNormal,
+		Amount:   amount,
+	})
+	s.broadcast <- types.Message{
+		Type: types.MsgEnemyUpdate,
+		Data: s.marshal(enemy),
+		Zone: enemy.Zone,
+	}
+}
+
+// enemyBuffSelf applies the ability's aura to the enemy
+func (s *GameServer) enemyBuffSelf(enemy *types.Enemy, ability game.EnemyAbility) {
+	aura, exists := game.NewAura(ability.AuraID, enemy.ID, time.Now())
+	if !exists {
+		log.Printf("Enemy %s used %s but aura %s does not exist", enemy.Name, ability.Name, ability.AuraID)
+		return
+	}
+
+	enemy.Auras = game.ApplyAura(enemy.Auras, aura)
+	s.refreshEnemyStats(enemy)
+	log.Printf("Enemy %s used %s", enemy.Name, ability.Name)
+
+	s.broadcast <- types.Message{
+		Type: types.MsgEnemyUpdate,
+		Data: s.marshal(enemy),
+		Zone: enemy.Zone,
+	}
+}
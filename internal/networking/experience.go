@@ -0,0 +1,88 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"slices"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// killCredit returns the players who share the loot and experience of a kill:
+// everyone the enemy was fighting, whoever landed the killing blow, and their
+// party members close enough to the kill
+func (s *GameServer) killCredit(enemy *types.Enemy, killerID string) []*types.Player {
+	var credited []*types.Player
+	for _, player := range s.players {
+		_, onThreatList := enemy.ThreatList[player.ID]
+		if onThreatList || player.ID == killerID {
+			credited = append(credited, player)
+		}
+	}
+
+	for _, player := range credited {
+		for _, member := range s.partyMembers(player) {
+			if slices.Contains(credited, member) {
+				continue
+			}
+			if member.Zone == enemy.Zone && math.Hypot(member.X-enemy.X, member.Y-enemy.Y) <= game.PartyShareRange {
+				credited = append(credited, member)
+			}
+		}
+	}
+	return credited
+}
+
+// awardKillXP splits the experience for a kill between the players credited with it
+func (s *GameServer) awardKillXP(enemy *types.Enemy, killerID string) {
+	credited := s.killCredit(enemy, killerID)
+
+	levels := make([]int, len(credited))
+	for i, player := range credited {
+		levels[i] = player.Level
+	}
+
+	shares := game.GroupKillXP(enemy.Level, enemy.Boss, levels)
+	for i, player := range credited {
+		s.giveXP(player, shares[i], enemy.Name)
+	}
+}
+
+// giveXP awards experience to a player, levelling them up when they have earned enough
+func (s *GameServer) giveXP(player *types.Player, amount int, source string) {
+	if amount <= 0 || player.Level >= game.MaxLevel {
+		return
+	}
+
+	levels := game.GainXP(player, amount)
+	log.Printf("Player %s gained %d experience from %s (level %d, %d/%d)",
+		player.Name, amount, source, player.Level, player.XP, game.XPToNextLevel(player.Level))
+
+	s.broadcast <- types.Message{
+		Type:       types.MsgXPGain,
+		PlayerID:   player.ID,
+		Data:       s.marshal(types.XPGain{Amount: amount, Source: source}),
+		Recipients: []string{player.ID},
+	}
+
+	if levels > 0 {
+		// Levelling up fully restores health, and the class resource if it starts full
+		s.refreshPlayerStats(player)
+		if !player.Dead {
+			player.Health = player.MaxHealth
+			if game.ClassResource(player.Class).StartsFull {
+				player.Mana = player.MaxMana
+			}
+		}
+		log.Printf("Player %s reached level %d", player.Name, player.Level)
+
+		s.broadcast <- types.Message{
+			Type:     types.MsgLevelUp,
+			PlayerID: player.ID,
+			Data:     s.marshal(map[string]int{"level": player.Level}),
+		}
+	}
+
+	s.broadcastPlayerUpdate(player)
+}
@@ -0,0 +1,174 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	healManaCost  = 35
+	renewManaCost = 25
+	healRange     = 250.0 // Pixels between the healer and their target
+)
+
+func (s *GameServer) handleHeal(caster *types.Player, targetPlayerID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if caster.Class != "priest" {
+		log.Printf("Player %s (%s) attempted heal but is not a priest", caster.Name, caster.Class)
+		return
+	}
+	if caster.Dead {
+		log.Printf("Player %s attempted heal but is dead", caster.Name)
+		return
+	}
+
+	if caster.Mana < healManaCost {
+		log.Printf("Player %s attempted heal but lacks mana (%d/%d)", caster.Name, caster.Mana, healManaCost)
+		return
+	}
+
+	target, ok := s.friendlySpellTarget(caster, targetPlayerID, "heal")
+	if !ok {
+		return
+	}
+
+	s.spendResource(caster, healManaCost)
+
+	amount := game.RollSpellDamage(s.rng, 18, 24, caster.Derived.SpellPower, 1.0)
+	roll := game.RollHeal(s.rng, amount, caster.Derived)
+	s.applyHeal(caster, target, "heal", roll)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: caster.ID,
+		Data:     s.marshal(caster),
+	}
+}
+
+func (s *GameServer) handleRenew(caster *types.Player, targetPlayerID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if caster.Class != "priest" {
+		log.Printf("Player %s (%s) attempted renew but is not a priest", caster.Name, caster.Class)
+		return
+	}
+	if caster.Dead {
+		log.Printf("Player %s attempted renew but is dead", caster.Name)
+		return
+	}
+
+	if caster.Mana < renewManaCost {
+		log.Printf("Player %s attempted renew but lacks mana (%d/%d)", caster.Name, caster.Mana, renewManaCost)
+		return
+	}
+
+	target, ok := s.friendlySpellTarget(caster, targetPlayerID, "renew")
+	if !ok {
+		return
+	}
+
+	aura, _ := game.NewAura("renew", caster.ID, time.Now())
+
+	s.spendResource(caster, renewManaCost)
+	target.Auras = game.ApplyAura(target.Auras, aura)
+	s.refreshPlayerStats(target)
+	log.Printf("Player %s applied Renew to %s", caster.Name, target.Name)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: caster.ID,
+		Data:     s.marshal(caster),
+	}
+	if target.ID != caster.ID {
+		s.broadcast <- types.Message{
+			Type:     types.MsgPlayerUpdate,
+			PlayerID: target.ID,
+			Data:     s.marshal(target),
+		}
+	}
+}
+
+// friendlySpellTarget resolves the living player a beneficial spell is aimed at.
+// An empty target ID means the caster is targeting themselves.
+func (s *GameServer) friendlySpellTarget(caster *types.Player, targetPlayerID, ability string) (*types.Player, bool) {
+	if targetPlayerID == "" || targetPlayerID == caster.ID {
+		return caster, true
+	}
+
+	target, exists := s.players[targetPlayerID]
+	if !exists || target.Zone != caster.Zone {
+		log.Printf("%s: Player %s not found", ability, targetPlayerID)
+		return nil, false
+	}
+
+	if target.Dead {
+		log.Printf("Player %s attempted %s on %s but they are dead", caster.Name, ability, target.Name)
+		return nil, false
+	}
+
+	dx := target.X - caster.X
+	dy := target.Y - caster.Y
+	if math.Sqrt(dx*dx+dy*dy) > healRange {
+		log.Printf("Player %s attempted %s on %s but is out of range", caster.Name, ability, target.Name)
+		return nil, false
+	}
+
+	return target, true
+}
+
+// applyHeal restores a rolled heal to a player and generates threat for the healer.
+// Overhealing is reported in the log but generates no threat.
+func (s *GameServer) applyHeal(healer, target *types.Player, ability string, roll game.AttackRoll) {
+	effective := min(roll.Damage, target.MaxHealth-target.Health)
+	target.Health += effective
+	log.Printf("Player %s's %s healed %s for %d (%d overheal) (%s) (HP: %d/%d)",
+		healer.Name, ability, target.Name, effective, roll.Damage-effective, roll.HitType, target.Health, target.MaxHealth)
+
+	s.broadcastCombatEvent(types.CombatEvent{
+		Kind:     types.CombatHeal,
+		SourceID: healer.ID,
+		TargetID: target.ID,
+		Ability:  ability,
+		HitType:  roll.HitType,
+		Amount:   effective,
+	})
+
+	s.addHealingThreat(healer, target, ability, effective)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: target.ID,
+		Data:     s.marshal(target),
+	}
+}
+
+// addHealingThreat splits the threat from healing a player evenly across every
+// enemy that has that player on its threat list
+func (s *GameServer) addHealingThreat(healer, target *types.Player, ability string, amount int) {
+	if amount <= 0 {
+		return
+	}
+
+	var engaged []*types.Enemy
+	for _, enemy := range s.enemies {
+		if _, inCombat := enemy.ThreatList[target.ID]; inCombat {
+			engaged = append(engaged, enemy)
+		}
+	}
+
+	if len(engaged) == 0 {
+		return
+	}
+
+	threat := float64(amount) / float64(len(engaged))
+	for _, enemy := range engaged {
+		s.addThreat(enemy, healer.ID, ability, threat)
+	}
+}
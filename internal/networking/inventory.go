@@ -0,0 +1,108 @@
+package networking
+
+import (
+	"log"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// handleEquip wears the item in a bag slot, swapping out anything already in its equipment slot
+func (s *GameServer) handleEquip(player *types.Player, bagSlot int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if player.Dead {
+		log.Printf("Player %s attempted to equip an item but is dead", player.Name)
+		return
+	}
+
+	var itemID string
+	if bagSlot >= 0 && bagSlot < len(player.Bag) {
+		itemID = player.Bag[bagSlot].ItemID
+	}
+
+	if err := game.EquipItem(player, bagSlot); err != nil {
+		log.Printf("Player %s attempted to equip bag slot %d: %v", player.Name, bagSlot, err)
+		s.sendError(player, err.Error())
+		return
+	}
+
+	log.Printf("Player %s equipped %s", player.Name, game.ItemName(itemID))
+	s.afterEquipmentChange(player)
+}
+
+// handleUnequip takes off the item in an equipment slot and puts it in the bag.
+// A negative bag slot puts it in the first empty one.
+func (s *GameServer) handleUnequip(player *types.Player, slot string, bagSlot int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if player.Dead {
+		log.Printf("Player %s attempted to unequip an item but is dead", player.Name)
+		return
+	}
+
+	if err := game.UnequipItem(player, types.EquipSlot(slot), bagSlot); err != nil {
+		log.Printf("Player %s attempted to unequip %s: %v", player.Name, slot, err)
+		s.sendError(player, err.Error())
+		return
+	}
+
+	log.Printf("Player %s unequipped their %s", player.Name, slot)
+	s.afterEquipmentChange(player)
+}
+
+// handleDestroyItem throws away the stack in a bag slot
+func (s *GameServer) handleDestroyItem(player *types.Player, bagSlot int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	destroyed, err := game.DestroyItem(player, bagSlot)
+	if err != nil {
+		log.Printf("Player %s attempted to destroy bag slot %d: %v", player.Name, bagSlot, err)
+		s.sendError(player, err.Error())
+		return
+	}
+
+	log.Printf("Player %s destroyed %dx %s", player.Name, destroyed.Count, game.ItemName(destroyed.ItemID))
+	s.broadcastPlayerUpdate(player)
+}
+
+// handleMoveItem moves a stack between bag slots
+func (s *GameServer) handleMoveItem(player *types.Player, from, to int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := game.MoveBagItem(player.Bag, from, to); err != nil {
+		log.Printf("Player %s attempted to move bag slot %d to %d: %v", player.Name, from, to, err)
+		s.sendError(player, err.Error())
+		return
+	}
+
+	s.broadcastPlayerUpdate(player)
+}
+
+// afterEquipmentChange picks up the player's new weapon and stat bonuses
+func (s *GameServer) afterEquipmentChange(player *types.Player) {
+	player.Weapon = game.EquippedWeapon(player)
+	s.refreshPlayerStats(player)
+	s.broadcastPlayerUpdate(player)
+}
+
+func (s *GameServer) broadcastPlayerUpdate(player *types.Player) {
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: player.ID,
+		Data:     s.marshal(player),
+	}
+}
+
+// sendError tells a single player why their request was refused
+func (s *GameServer) sendError(player *types.Player, text string) {
+	s.broadcast <- types.Message{
+		Type:       types.MsgError,
+		Data:       s.marshal(text),
+		Recipients: []string{player.ID},
+	}
+}
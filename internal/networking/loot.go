@@ -0,0 +1,262 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const corpseDecay = 60 * time.Second // How long a corpse can be looted before it disappears
+
+// spawnCorpse rolls a dead enemy's loot and leaves a corpse for the players who
+// fought it. Enemies that drop nothing leave no corpse.
+func (s *GameServer) spawnCorpse(enemy *types.Enemy, killerID string) {
+	gold, items := game.RollLoot(s.rng, game.EnemyLoot(enemy.EnemyType))
+	if gold == 0 && len(items) == 0 {
+		return
+	}
+
+	eligible := s.killCredit(enemy, killerID)
+	if len(eligible) == 0 {
+		return
+	}
+
+	// Each party shares its members' claim by its own loot rule; solo players may always loot
+	looters := make([]string, 0, len(eligible))
+	for _, group := range s.lootGroups(eligible) {
+		party, inParty := s.parties[group[0].PartyID]
+		if inParty && party.LootRule == types.LootRoundRobin && len(group) > 1 {
+			owner := s.players[game.NextRoundRobinLooter(group)]
+			owner.LastLootTurn = time.Now()
+			looters = append(looters, owner.ID)
+			continue
+		}
+		for _, player := range group {
+			looters = append(looters, player.ID)
+		}
+	}
+	slices.Sort(looters)
+
+	corpse := &types.Corpse{
+		ID:        enemy.ID,
+		Name:      enemy.Name,
+		EnemyType: enemy.EnemyType,
+		X:         enemy.X,
+		Y:         enemy.Y,
+		Zone:      enemy.Zone,
+		Looters:   looters,
+		ExpiresAt: time.Now().Add(corpseDecay).UnixMilli(),
+		Gold:      gold,
+		Items:     items,
+	}
+	s.corpses[corpse.ID] = corpse
+	log.Printf("Enemy %s left a corpse with %d gold and %d items for %d looters", enemy.Name, gold, len(items), len(looters))
+
+	s.broadcast <- types.Message{
+		Type: types.MsgCorpseSpawn,
+		Data: s.marshal(corpse),
+		Zone: corpse.Zone,
+	}
+}
+
+// lootGroups splits the players credited with a kill by party. Players who are
+// not in a party each make up a group of their own.
+func (s *GameServer) lootGroups(eligible []*types.Player) [][]*types.Player {
+	var groups [][]*types.Player
+	partyGroups := make(map[string]int) // Party ID -> index in groups
+	for _, player := range eligible {
+		if index, seen := partyGroups[player.PartyID]; seen && player.PartyID != "" {
+			groups[index] = append(groups[index], player)
+			continue
+		}
+		partyGroups[player.PartyID] = len(groups)
+		groups = append(groups, []*types.Player{player})
+	}
+	return groups
+}
+
+// handleCorpses removes corpses once they decay
+func (s *GameServer) handleCorpses() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mutex.Lock()
+
+		now := time.Now().UnixMilli()
+		for _, corpse := range s.corpses {
+			if now >= corpse.ExpiresAt {
+				s.removeCorpse(corpse)
+			}
+		}
+
+		s.mutex.Unlock()
+	}
+}
+
+func (s *GameServer) removeCorpse(corpse *types.Corpse) {
+	delete(s.corpses, corpse.ID)
+
+	s.broadcast <- types.Message{
+		Type: types.MsgCorpseRemove,
+		Data: s.marshal(map[string]string{"id": corpse.ID}),
+		Zone: corpse.Zone,
+	}
+}
+
+// lootableCorpse returns a corpse the player is allowed to loot and standing next to
+func (s *GameServer) lootableCorpse(player *types.Player, corpseID string) (*types.Corpse, bool) {
+	corpse, exists := s.corpses[corpseID]
+	if !exists {
+		log.Printf("Loot: Corpse %s not found", corpseID)
+		return nil, false
+	}
+
+	if player.Dead || corpse.Zone != player.Zone || !slices.Contains(corpse.Looters, player.ID) {
+		log.Printf("Player %s attempted to loot %s but is not allowed to", player.Name, corpse.Name)
+		return nil, false
+	}
+
+	dx := corpse.X - player.X
+	dy := corpse.Y - player.Y
+	if math.Sqrt(dx*dx+dy*dy) > game.LootRange {
+		log.Printf("Player %s attempted to loot %s but is too far away", player.Name, corpse.Name)
+		return nil, false
+	}
+
+	return corpse, true
+}
+
+// handleLootOpen sends a corpse's contents to a player who wants to loot it
+func (s *GameServer) handleLootOpen(player *types.Player, corpseID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	corpse, ok := s.lootableCorpse(player, corpseID)
+	if !ok {
+		return
+	}
+
+	s.sendLootWindow(player, corpse)
+}
+
+// handleLootGold gives the player all the gold on a corpse
+func (s *GameServer) handleLootGold(player *types.Player, corpseID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	corpse, ok := s.lootableCorpse(player, corpseID)
+	if !ok || corpse.Gold == 0 {
+		return
+	}
+
+	player.Gold += corpse.Gold
+	log.Printf("Player %s looted %d gold from %s", player.Name, corpse.Gold, corpse.Name)
+	corpse.Gold = 0
+
+	s.afterLoot(player, corpse)
+}
+
+// handleLootItem moves one stack of items from a corpse into the player's bag,
+// leaving whatever does not fit on the corpse
+func (s *GameServer) handleLootItem(player *types.Player, corpseID string, slot int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	corpse, ok := s.lootableCorpse(player, corpseID)
+	if !ok {
+		return
+	}
+
+	if slot < 0 || slot >= len(corpse.Items) {
+		log.Printf("Player %s attempted to loot slot %d of %s, which is empty", player.Name, slot, corpse.Name)
+		s.sendLootWindow(player, corpse)
+		return
+	}
+
+	item := corpse.Items[slot]
+	leftover := game.AddToBag(player.Bag, item)
+	if leftover == item.Count {
+		s.sendError(player, game.ErrBagFull.Error())
+		return
+	}
+
+	if leftover > 0 {
+		corpse.Items[slot].Count = leftover
+	} else {
+		corpse.Items = slices.Delete(corpse.Items, slot, slot+1)
+	}
+	log.Printf("Player %s looted %dx %s from %s", player.Name, item.Count-leftover, game.ItemName(item.ItemID), corpse.Name)
+
+	s.afterLoot(player, corpse)
+}
+
+// afterLoot updates the looter and removes the corpse once it has been emptied
+func (s *GameServer) afterLoot(player *types.Player, corpse *types.Corpse) {
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: player.ID,
+		Data:     s.marshal(player),
+	}
+
+	if corpse.Gold == 0 && len(corpse.Items) == 0 {
+		s.removeCorpse(corpse)
+		return
+	}
+
+	s.sendLootWindow(player, corpse)
+}
+
+func (s *GameServer) sendLootWindow(player *types.Player, corpse *types.Corpse) {
+	s.broadcast <- types.Message{
+		Type: types.MsgLootWindow,
+		Data: s.marshal(types.LootWindow{
+			CorpseID: corpse.ID,
+			Gold:     corpse.Gold,
+			Items:    corpse.Items,
+		}),
+		Recipients: []string{player.ID},
+	}
+}
+
+// handleSetLootRule changes how the corpses of enemies the leader's party kills are shared out
+func (s *GameServer) handleSetLootRule(player *types.Player, rule string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	party, inParty := s.parties[player.PartyID]
+	if !inParty {
+		s.sendError(player, "you must be in a party to set a loot rule")
+		return
+	}
+	if party.LeaderID != player.ID {
+		s.sendError(player, "only the party leader can set the loot rule")
+		return
+	}
+
+	switch types.LootRule(rule) {
+	case types.LootFreeForAll, types.LootRoundRobin:
+		party.LootRule = types.LootRule(rule)
+	default:
+		log.Printf("Player %s attempted to set unknown loot rule %q", player.Name, rule)
+		return
+	}
+
+	log.Printf("Player %s set their party's loot rule to %s", player.Name, party.LootRule)
+
+	recipients := make([]string, 0, len(party.Members))
+	for _, member := range party.Members {
+		recipients = append(recipients, member.ID)
+	}
+	s.broadcast <- types.Message{
+		Type:       types.MsgLootRule,
+		PlayerID:   player.ID,
+		Data:       s.marshal(map[string]string{"rule": string(party.LootRule)}),
+		Recipients: recipients,
+	}
+	s.sendPartyUpdate(party)
+}
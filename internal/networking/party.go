@@ -0,0 +1,233 @@
+package networking
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/google/uuid"
+)
+
+const partyInviteTimeout = time.Minute // How long an invite can be accepted for
+
+// partyInvite is an open invitation waiting for the invited player to answer
+type partyInvite struct {
+	fromID    string
+	expiresAt time.Time
+}
+
+// handlePartyInvite invites another player to the inviter's party, starting a new
+// party if the inviter is not in one yet
+func (s *GameServer) handlePartyInvite(inviter *types.Player, targetID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	target, exists := s.players[targetID]
+	if !exists || target.ID == inviter.ID {
+		log.Printf("Player %s attempted to invite unknown player %s", inviter.Name, targetID)
+		return
+	}
+
+	if party, inParty := s.parties[inviter.PartyID]; inParty {
+		if party.LeaderID != inviter.ID {
+			s.sendError(inviter, "only the party leader can invite players")
+			return
+		}
+		if len(party.Members) >= game.MaxPartySize {
+			s.sendError(inviter, game.ErrPartyFull.Error())
+			return
+		}
+	}
+
+	if target.PartyID != "" {
+		s.sendError(inviter, fmt.Sprintf("%s is already in a party", target.Name))
+		return
+	}
+
+	s.partyInvites[target.ID] = partyInvite{fromID: inviter.ID, expiresAt: time.Now().Add(partyInviteTimeout)}
+	log.Printf("Player %s invited %s to a party", inviter.Name, target.Name)
+
+	s.sendNotice(inviter, fmt.Sprintf("You have invited %s to join your party", target.Name))
+	s.broadcast <- types.Message{
+		Type:       types.MsgPartyInvite,
+		Data:       s.marshal(types.PartyInvite{FromID: inviter.ID, FromName: inviter.Name}),
+		Recipients: []string{target.ID},
+	}
+}
+
+// handlePartyAccept joins the party the player was last invited to
+func (s *GameServer) handlePartyAccept(player *types.Player) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	invite, exists := s.partyInvites[player.ID]
+	delete(s.partyInvites, player.ID)
+	if !exists || time.Now().After(invite.expiresAt) {
+		s.sendError(player, "that invitation has expired")
+		return
+	}
+
+	inviter, online := s.players[invite.fromID]
+	if !online {
+		s.sendError(player, "that invitation has expired")
+		return
+	}
+	if player.PartyID != "" {
+		s.sendError(player, "you are already in a party")
+		return
+	}
+
+	party, inParty := s.parties[inviter.PartyID]
+	if !inParty {
+		party = &types.Party{ID: uuid.New().String(), LeaderID: inviter.ID, LootRule: types.LootFreeForAll}
+		game.AddPartyMember(party, inviter)
+		inviter.PartyID = party.ID
+		s.parties[party.ID] = party
+	}
+
+	if err := game.AddPartyMember(party, player); err != nil {
+		s.sendError(player, err.Error())
+		return
+	}
+	player.PartyID = party.ID
+	log.Printf("Player %s joined %s's party", player.Name, inviter.Name)
+
+	s.partyNotice(party, fmt.Sprintf("%s joins the party", player.Name))
+	s.sendPartyUpdate(party)
+}
+
+// handlePartyDecline turns down the player's open invitation
+func (s *GameServer) handlePartyDecline(player *types.Player) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	invite, exists := s.partyInvites[player.ID]
+	if !exists {
+		return
+	}
+	delete(s.partyInvites, player.ID)
+
+	if inviter, online := s.players[invite.fromID]; online {
+		s.sendNotice(inviter, fmt.Sprintf("%s declines your party invitation", player.Name))
+	}
+}
+
+// handlePartyLeave takes the player out of their party
+func (s *GameServer) handlePartyLeave(player *types.Player) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if player.PartyID == "" {
+		return
+	}
+
+	s.leaveParty(player, fmt.Sprintf("%s leaves the party", player.Name))
+	s.sendNotice(player, "You leave the party")
+}
+
+// handlePartyKick lets the party leader remove another member
+func (s *GameServer) handlePartyKick(leader *types.Player, targetID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	party, inParty := s.parties[leader.PartyID]
+	if !inParty || party.LeaderID != leader.ID {
+		s.sendError(leader, "only the party leader can remove players")
+		return
+	}
+
+	target, exists := s.players[targetID]
+	if !exists || target.PartyID != party.ID || target.ID == leader.ID {
+		log.Printf("Player %s attempted to kick %s, who is not in their party", leader.Name, targetID)
+		return
+	}
+
+	log.Printf("Player %s removed %s from the party", leader.Name, target.Name)
+	s.leaveParty(target, fmt.Sprintf("%s has been removed from the party", target.Name))
+	s.sendNotice(target, "You have been removed from the party")
+}
+
+// leaveParty removes a player from their party, disbanding it once only one member is left
+func (s *GameServer) leaveParty(player *types.Player, notice string) {
+	party, inParty := s.parties[player.PartyID]
+	player.PartyID = ""
+	s.sendParty(player, nil)
+	if !inParty {
+		return
+	}
+
+	game.RemovePartyMember(party, player.ID)
+	if len(party.Members) > 1 {
+		s.partyNotice(party, notice)
+		s.sendPartyUpdate(party)
+		return
+	}
+
+	delete(s.parties, party.ID)
+	for _, member := range party.Members {
+		if remaining, online := s.players[member.ID]; online {
+			remaining.PartyID = ""
+			s.sendNotice(remaining, "Your party has been disbanded")
+			s.sendParty(remaining, nil)
+		}
+	}
+	log.Printf("Party %s disbanded", party.ID)
+}
+
+// partyMembers returns the online members of a player's party, or just the player when they are not in one
+func (s *GameServer) partyMembers(player *types.Player) []*types.Player {
+	party, inParty := s.parties[player.PartyID]
+	if !inParty {
+		return []*types.Player{player}
+	}
+
+	members := make([]*types.Player, 0, len(party.Members))
+	for _, member := range party.Members {
+		if online, exists := s.players[member.ID]; exists {
+			members = append(members, online)
+		}
+	}
+	return members
+}
+
+func (s *GameServer) sendPartyUpdate(party *types.Party) {
+	for _, member := range party.Members {
+		if player, online := s.players[member.ID]; online {
+			s.sendParty(player, party)
+		}
+	}
+}
+
+// sendParty tells a player which party they are in; nil means none
+func (s *GameServer) sendParty(player *types.Player, party *types.Party) {
+	s.broadcast <- types.Message{
+		Type:       types.MsgPartyUpdate,
+		Data:       s.marshal(party),
+		Recipients: []string{player.ID},
+	}
+}
+
+// partyNotice tells every member of a party about a change to it
+func (s *GameServer) partyNotice(party *types.Party, text string) {
+	recipients := make([]string, 0, len(party.Members))
+	for _, member := range party.Members {
+		recipients = append(recipients, member.ID)
+	}
+
+	s.broadcast <- types.Message{
+		Type:       types.MsgNotice,
+		Data:       s.marshal(text),
+		Recipients: recipients,
+	}
+}
+
+// sendNotice shows a system message to a single player
+func (s *GameServer) sendNotice(player *types.Player, text string) {
+	s.broadcast <- types.Message{
+		Type:       types.MsgNotice,
+		Data:       s.marshal(text),
+		Recipients: []string{player.ID},
+	}
+}
@@ -0,0 +1,146 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+	"github.com/google/uuid"
+)
+
+const (
+	projectileHitRadius = 10.0 // Pixels from the target's center that counts as an impact
+	rangedReachSlack    = 20.0 // Extra pixels allowed for client and server positions disagreeing
+)
+
+// launchProjectile fires a homing projectile carrying an already rolled attack.
+// The source and target may each be a player or an enemy.
+func (s *GameServer) launchProjectile(sourceID, targetID, ability, style string, speed float64, roll game.AttackRoll) {
+	x, y, zone, exists := s.entityPosition(sourceID)
+	if !exists {
+		return
+	}
+
+	projectile := &types.Projectile{
+		ID:        uuid.New().String(),
+		SourceID:  sourceID,
+		TargetID:  targetID,
+		Ability:   ability,
+		Style:     style,
+		X:         x,
+		Y:         y,
+		Speed:     speed,
+		Damage:    roll.Damage,
+		Mitigated: roll.Mitigated,
+		HitType:   roll.HitType,
+		Zone:      zone,
+	}
+	s.projectiles[projectile.ID] = projectile
+
+	s.broadcast <- types.Message{
+		Type: types.MsgProjectileSpawn,
+		Data: s.marshal(projectile),
+		Zone: zone,
+	}
+}
+
+// launchWeaponShot fires a ranged weapon's auto attack, rolling it with the weapon's combat table
+func (s *GameServer) launchWeaponShot(sourceID, targetID string, weapon *types.Weapon, damage int, attacker, defender types.DerivedStats) {
+	roll := game.RollAutoAttack(s.rng, weapon, damage, attacker, defender)
+
+	style := "arrow"
+	if game.IsMagicWeapon(weapon) {
+		style = "wand"
+	}
+
+	s.launchProjectile(sourceID, targetID, "shoot", style, game.ProjectileSpeed(weapon), roll)
+}
+
+// hasLineOfSight reports whether no wall of a zone stands between two points in it
+func (s *GameServer) hasLineOfSight(zone string, x1, y1, x2, y2 float64) bool {
+	return !game.SegmentHitsWall(x1, y1, x2, y2, s.rooms[zone].Walls)
+}
+
+func (s *GameServer) handleProjectiles() {
+	tickRate := 50 * time.Millisecond
+	ticker := time.NewTicker(tickRate)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mutex.Lock()
+
+		for _, projectile := range s.projectiles {
+			s.updateProjectile(projectile, tickRate.Seconds())
+		}
+
+		s.mutex.Unlock()
+	}
+}
+
+// updateProjectile moves a projectile toward its target, stopping it at walls
+// and resolving the hit on arrival
+func (s *GameServer) updateProjectile(projectile *types.Projectile, elapsed float64) {
+	// Targets that left the zone can no longer be hit
+	targetX, targetY, zone, exists := s.entityPosition(projectile.TargetID)
+	if !exists || zone != projectile.Zone {
+		s.removeProjectile(projectile)
+		return
+	}
+
+	dx := targetX - projectile.X
+	dy := targetY - projectile.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	step := projectile.Speed * elapsed
+
+	if distance <= step+projectileHitRadius {
+		s.removeProjectile(projectile)
+		s.resolveProjectileHit(projectile)
+		return
+	}
+
+	nextX := projectile.X + dx/distance*step
+	nextY := projectile.Y + dy/distance*step
+
+	if !s.hasLineOfSight(projectile.Zone, projectile.X, projectile.Y, nextX, nextY) {
+		log.Printf("Projectile %s (%s) hit a wall at (%.0f, %.0f)", projectile.ID[:8], projectile.Ability, projectile.X, projectile.Y)
+		s.removeProjectile(projectile)
+		return
+	}
+
+	projectile.X = nextX
+	projectile.Y = nextY
+}
+
+// resolveProjectileHit applies a projectile's rolled attack to whatever it was aimed at
+func (s *GameServer) resolveProjectileHit(projectile *types.Projectile) {
+	roll := game.AttackRoll{
+		HitType:   projectile.HitType,
+		Damage:    projectile.Damage,
+		Mitigated: projectile.Mitigated,
+	}
+
+	if source, exists := s.players[projectile.SourceID]; exists {
+		if target, exists := s.enemies[projectile.TargetID]; exists {
+			s.applySpellDamage(source, target, projectile.Ability, roll)
+		}
+		return
+	}
+
+	if source, exists := s.enemies[projectile.SourceID]; exists {
+		if target, exists := s.players[projectile.TargetID]; exists && !target.Dead {
+			s.applyEnemyDamage(source, target, projectile.Ability, roll)
+		}
+	}
+}
+
+func (s *GameServer) removeProjectile(projectile *types.Projectile) {
+	delete(s.projectiles, projectile.ID)
+
+	s.broadcast <- types.Message{
+		Type: types.MsgProjectileRemove,
+		Data: s.marshal(map[string]string{"id": projectile.ID}),
+		Zone: projectile.Zone,
+	}
+}
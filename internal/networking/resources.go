@@ -0,0 +1,39 @@
+package networking
+
+import (
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// handleResourceRegen regenerates or decays every player's class resource once a second
+func (s *GameServer) handleResourceRegen() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mutex.Lock()
+
+		now := time.Now()
+		for _, player := range s.players {
+			if !game.RegenerateResource(player, game.ClassResource(player.Class), now) {
+				continue
+			}
+
+			s.broadcast <- types.Message{
+				Type:     types.MsgPlayerUpdate,
+				PlayerID: player.ID,
+				Data:     s.marshal(player),
+			}
+		}
+
+		s.mutex.Unlock()
+	}
+}
+
+// spendResource deducts the class resource and pauses regeneration that waits on spending
+func (s *GameServer) spendResource(player *types.Player, cost int) {
+	player.Mana -= cost
+	player.LastSpend = time.Now()
+}
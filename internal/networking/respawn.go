@@ -0,0 +1,153 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	respawnDelay       = 5 * time.Second // Time between releasing and respawning
+	respawnHealthPct   = 0.5             // Health restored when respawning at the graveyard
+	resurrectHealthPct = 0.35            // Health restored by a resurrection
+	resurrectRange     = 100.0           // Pixels between the caster and the corpse
+	resurrectCooldown  = 30 * time.Second
+)
+
+// handleRelease starts the respawn timer for a dead player who gave up waiting for a resurrection
+func (s *GameServer) handleRelease(player *types.Player) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !player.Dead || player.Released {
+		return
+	}
+
+	player.Released = true
+	player.RespawnAt = time.Now().Add(respawnDelay).UnixMilli()
+	log.Printf("Player %s released their spirit", player.Name)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: player.ID,
+		Data:     s.marshal(player),
+	}
+}
+
+func (s *GameServer) handleRespawns() {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mutex.Lock()
+
+		nowMillis := time.Now().UnixMilli()
+		for _, player := range s.players {
+			if player.Released && player.RespawnAt <= nowMillis {
+				s.respawnPlayer(player)
+			}
+		}
+
+		s.mutex.Unlock()
+	}
+}
+
+// respawnPlayer revives a released player at the graveyard with partial health
+func (s *GameServer) respawnPlayer(player *types.Player) {
+	graveyard := s.rooms[player.Zone].Graveyard
+
+	s.revivePlayer(player, respawnHealthPct)
+	player.X = graveyard.X
+	player.Y = graveyard.Y
+	log.Printf("Player %s respawned at the graveyard (%.0f, %.0f)", player.Name, player.X, player.Y)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerTeleport,
+		PlayerID: player.ID,
+		Data: s.marshal(map[string]float64{
+			"x": player.X,
+			"y": player.Y,
+		}),
+	}
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: player.ID,
+		Data:     s.marshal(player),
+	}
+}
+
+// handleResurrect revives another player's corpse in place
+func (s *GameServer) handleResurrect(caster *types.Player, targetPlayerID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if caster.Dead {
+		log.Printf("Player %s attempted resurrect but is dead", caster.Name)
+		return
+	}
+	if s.isOnCooldown(caster, "resurrect") {
+		log.Printf("Player %s attempted resurrect but it is on cooldown", caster.Name)
+		return
+	}
+	if s.inCombat(caster) {
+		s.sendError(caster, "you cannot resurrect while in combat")
+		return
+	}
+
+	target, exists := s.players[targetPlayerID]
+	if !exists || target.ID == caster.ID || target.Zone != caster.Zone {
+		log.Printf("Resurrect: Player %s not found", targetPlayerID)
+		return
+	}
+
+	if !target.Dead || target.Released {
+		log.Printf("Player %s attempted to resurrect %s but there is no corpse", caster.Name, target.Name)
+		return
+	}
+
+	dx := target.X - caster.X
+	dy := target.Y - caster.Y
+	if math.Sqrt(dx*dx+dy*dy) > resurrectRange {
+		log.Printf("Player %s attempted to resurrect %s but is out of range", caster.Name, target.Name)
+		return
+	}
+
+	s.revivePlayer(target, resurrectHealthPct)
+	s.startCooldown(caster, "resurrect", resurrectCooldown)
+	log.Printf("Player %s resurrected %s", caster.Name, target.Name)
+
+	s.broadcastCombatEvent(types.CombatEvent{
+		Kind:     types.CombatResurrect,
+		SourceID: caster.ID,
+		TargetID: target.ID,
+		Ability:  "resurrect",
+	})
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: caster.ID,
+		Data:     s.marshal(caster),
+	}
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: target.ID,
+		Data:     s.marshal(target),
+	}
+}
+
+// revivePlayer brings a dead player back with a fraction of their max health
+func (s *GameServer) revivePlayer(player *types.Player, healthPct float64) {
+	player.Dead = false
+	player.Released = false
+	player.RespawnAt = 0
+	player.Health = max(1, int(float64(player.MaxHealth)*healthPct))
+
+	// Resources built up in combat come back empty; everything else keeps the same share as health
+	if game.ClassResource(player.Class).StartsFull {
+		player.Mana = int(float64(player.MaxMana) * healthPct)
+	} else {
+		player.Mana = 0
+	}
+}
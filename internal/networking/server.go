@@ -2,9 +2,12 @@ package networking
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"math"
+	"math/rand"
 	"net/http"
+	"slices"
 	"sync"
 	"time"
 
@@ -15,20 +18,36 @@ import (
 )
 
 type GameServer struct {
-	players   map[string]*types.Player
-	enemies   map[string]*types.Enemy
-	room      types.Room
-	mutex     sync.RWMutex
-	upgrader  websocket.Upgrader
-	broadcast chan types.Message
+	players        map[string]*types.Player
+	enemies        map[string]*types.Enemy
+	projectiles    map[string]*types.Projectile
+	groundEffects  map[string]*groundEffect
+	corpses        map[string]*types.Corpse
+	parties        map[string]*types.Party
+	partyInvites   map[string]partyInvite // Invited player ID -> their open invite
+	chatAllowances map[string]*chatAllowance
+	rooms          map[string]types.Room // Zone ID -> its map
+	mutex          sync.RWMutex
+	upgrader       websocket.Upgrader
+	broadcast      chan types.Message
+	rng            game.RNG    // Only used while holding mutex
+	audit          *log.Logger // Record of every admin command
 }
 
 func NewGameServer() *GameServer {
 	server := &GameServer{
-		players:   make(map[string]*types.Player),
-		enemies:   make(map[string]*types.Enemy),
-		room:      game.CreateDungeonRoom(),
-		broadcast: make(chan types.Message, 256),
+		players:        make(map[string]*types.Player),
+		enemies:        make(map[string]*types.Enemy),
+		projectiles:    make(map[string]*types.Projectile),
+		groundEffects:  make(map[string]*groundEffect),
+		corpses:        make(map[string]*types.Corpse),
+		parties:        make(map[string]*types.Party),
+		partyInvites:   make(map[string]partyInvite),
+		chatAllowances: make(map[string]*chatAllowance),
+		rooms:          createZoneRooms(),
+		broadcast:      make(chan types.Message, 256),
+		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
+		audit:          newAuditLog(),
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				// Allow all origins for development - restrict in production
@@ -39,7 +58,12 @@ func NewGameServer() *GameServer {
 
 	go server.handleBroadcast()
 	go server.handleEnemyAI()
-	go server.handleRageDecay()
+	go server.handleResourceRegen()
+	go server.handleAuras()
+	go server.handleRespawns()
+	go server.handleProjectiles()
+	go server.handleThreatUpdates()
+	go server.handleCorpses()
 
 	return server
 }
@@ -51,40 +75,43 @@ func (s *GameServer) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	playerID := uuid.New().String()
-	weaponID := uuid.New().String()
-	player := &types.Player{
-		ID:        playerID,
-		Name:      "Player " + playerID[:8],
-		X:         400,
-		Y:         300,
-		Class:     "warrior",
-		Health:    100,
-		MaxHealth: 100,
-		Mana:      0,
-		MaxMana:   100,
-		Conn:      conn,
-		Weapon: &types.Weapon{
-			ID:         weaponID,
-			Name:       "Wooden Sword",
-			Damage:     5,
-			Range:      1,
-			WeaponType: "sword",
-			Delay:      time.Second,
-		},
+	// The client stays on the creation screen until it picks a name nobody online has
+	var player *types.Player
+	var isFirstPlayer bool
+	for player == nil {
+		class, name, token, err := s.readCharacterCreation(conn)
+		if err != nil {
+			log.Printf("Character creation failed: %v", err)
+			conn.Close()
+			return
+		}
+
+		candidate := s.newPlayer(conn, class, name)
+		candidate.Role = staffRole(token)
+
+		var added bool
+		if isFirstPlayer, added = s.addPlayer(candidate); !added {
+			log.Printf("Character creation rejected: the name %s is already taken", candidate.Name)
+			conn.WriteJSON(types.Message{
+				Type: types.MsgError,
+				Data: s.marshal(fmt.Sprintf("the name %s is already taken", candidate.Name)),
+			})
+			continue
+		}
+		player = candidate
 	}
 
-	s.mutex.Lock()
-	s.players[playerID] = player
-	isFirstPlayer := len(s.players) == 1
-	s.mutex.Unlock()
+	playerID := player.ID
+	if player.Role != types.RolePlayer {
+		s.audit.Printf("%s (%s) logged in with role %q", player.Name, playerID, player.Role)
+	}
 
-	log.Printf("Player %s connected", playerID)
+	log.Printf("Player %s connected as %s (%s)", playerID, player.Name, player.Class)
 
 	// Spawn initial enemies if this is the first player
 	if isFirstPlayer {
 		log.Println("First player joined, spawning initial enemies")
-		go s.spawnInitialEnemies() // Run in separate goroutine to avoid race condition
+		s.populateWorld()
 	}
 
 	welcomeMsg := types.Message{
@@ -104,7 +131,7 @@ func (s *GameServer) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	s.mutex.RLock()
 	for existingPlayerID, existingPlayer := range s.players {
-		if existingPlayerID == playerID {
+		if existingPlayerID == playerID || existingPlayer.Zone != player.Zone {
 			continue
 		}
 
@@ -125,6 +152,10 @@ func (s *GameServer) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	if !isFirstPlayer {
 		for enemyID, enemy := range s.enemies {
+			if enemy.Zone != player.Zone {
+				continue
+			}
+
 			enemyMsg := types.Message{
 				Type: types.MsgEnemySpawn,
 				Data: s.marshal(enemy),
@@ -139,10 +170,29 @@ func (s *GameServer) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+
+	for corpseID, corpse := range s.corpses {
+		if corpse.Zone != player.Zone {
+			continue
+		}
+
+		corpseMsg := types.Message{
+			Type: types.MsgCorpseSpawn,
+			Data: s.marshal(corpse),
+		}
+
+		player.ConnMutex.Lock()
+		err := conn.WriteJSON(corpseMsg)
+		player.ConnMutex.Unlock()
+
+		if err != nil {
+			log.Printf("Error sending existing corpse %s data to new player: %v", corpseID, err)
+		}
+	}
 	
 	roomMsg := types.Message{
 		Type: types.MsgRoomData,
-		Data: s.marshal(s.room),
+		Data: s.marshal(s.rooms[player.Zone]),
 	}
 	player.ConnMutex.Lock()
 	err = conn.WriteJSON(roomMsg)
@@ -166,6 +216,11 @@ func (s *GameServer) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 func (s *GameServer) handlePlayerConnection(player *types.Player) {
 	defer func() {
 		s.mutex.Lock()
+		if player.PartyID != "" {
+			s.leaveParty(player, fmt.Sprintf("%s has gone offline", player.Name))
+		}
+		delete(s.partyInvites, player.ID)
+		delete(s.chatAllowances, player.ID)
 		delete(s.players, player.ID)
 		s.mutex.Unlock()
 
@@ -207,9 +262,17 @@ func (s *GameServer) handleMessage(player *types.Player, msg types.Message) {
 		}
 
 		s.mutex.Lock()
-		validX, validY := game.CheckWallCollisionWithSliding(player.X, player.Y, moveData.X, moveData.Y, s.room.Walls)
+		room := s.rooms[player.Zone]
+		validX, validY := game.CheckWallCollisionWithSliding(player.X, player.Y, moveData.X, moveData.Y, room.Walls)
 		player.X = validX
 		player.Y = validY
+
+		if portal := game.PortalAt(room, player.X, player.Y); portal != nil && !player.Dead {
+			entry := s.rooms[portal.ToZone].Entry
+			s.changeZone(player, portal.ToZone, entry.X, entry.Y)
+			s.mutex.Unlock()
+			return
+		}
 		s.mutex.Unlock()
 
 		s.broadcast <- types.Message{
@@ -218,10 +281,33 @@ func (s *GameServer) handleMessage(player *types.Player, msg types.Message) {
 			Data:     msg.Data,
 		}
 
+	case types.MsgChat:
+		var chat types.ChatMessage
+		if err := json.Unmarshal(msg.Data, &chat); err != nil {
+			log.Printf("Error unmarshaling chat message: %v", err)
+			return
+		}
+
+		s.handleChat(player, chat)
+
+	case types.MsgAdminCommand:
+		var command types.AdminCommand
+		if err := json.Unmarshal(msg.Data, &command); err != nil {
+			log.Printf("Error unmarshaling admin command: %v", err)
+			return
+		}
+
+		s.handleAdminCommand(player, command)
+
 	case types.MsgPlayerAction:
 		var actionData struct {
-			Action string `json:"action"`
-			Target string `json:"target,omitempty"`
+			Action string  `json:"action"`
+			Target string  `json:"target,omitempty"`
+			X      float64 `json:"x,omitempty"` // World position for cone and ground-targeted abilities
+			Y      float64 `json:"y,omitempty"`
+			Slot   int     `json:"slot,omitempty"`    // Loot window or bag slot
+			ToSlot int     `json:"to_slot,omitempty"` // Destination bag slot when moving items
+			Rule   string  `json:"rule,omitempty"`
 		}
 
 		if err := json.Unmarshal(msg.Data, &actionData); err != nil {
@@ -233,6 +319,66 @@ func (s *GameServer) handleMessage(player *types.Player, msg types.Message) {
 			s.handleCombat(player, actionData.Target)
 		} else if actionData.Action == "critical_strike" && actionData.Target != "" {
 			s.handleCriticalStrike(player, actionData.Target)
+		} else if actionData.Action == "rend" && actionData.Target != "" {
+			s.handleRend(player, actionData.Target)
+		} else if actionData.Action == "battle_shout" {
+			s.handleBattleShout(player)
+		} else if actionData.Action == "taunt" && actionData.Target != "" {
+			s.handleTaunt(player, actionData.Target)
+		} else if actionData.Action == "cleave" {
+			s.handleCleave(player, actionData.X, actionData.Y)
+		} else if actionData.Action == "frostbolt" && actionData.Target != "" {
+			s.handleFrostbolt(player, actionData.Target)
+		} else if actionData.Action == "frost_nova" {
+			s.handleFrostNova(player)
+		} else if actionData.Action == "flamestrike" {
+			s.handleFlamestrike(player, actionData.X, actionData.Y)
+		} else if actionData.Action == "sinister_strike" && actionData.Target != "" {
+			s.handleSinisterStrike(player, actionData.Target)
+		} else if actionData.Action == "eviscerate" && actionData.Target != "" {
+			s.handleEviscerate(player, actionData.Target)
+		} else if actionData.Action == "feint" && actionData.Target != "" {
+			s.handleFeint(player, actionData.Target)
+		} else if actionData.Action == "heal" {
+			s.handleHeal(player, actionData.Target)
+		} else if actionData.Action == "renew" {
+			s.handleRenew(player, actionData.Target)
+		} else if actionData.Action == "fade" {
+			s.handleFade(player)
+		} else if actionData.Action == "dispel_magic" && actionData.Target != "" {
+			s.handleDispelMagic(player, actionData.Target)
+		} else if actionData.Action == "loot_open" && actionData.Target != "" {
+			s.handleLootOpen(player, actionData.Target)
+		} else if actionData.Action == "loot_gold" && actionData.Target != "" {
+			s.handleLootGold(player, actionData.Target)
+		} else if actionData.Action == "loot_item" && actionData.Target != "" {
+			s.handleLootItem(player, actionData.Target, actionData.Slot)
+		} else if actionData.Action == "set_loot_rule" {
+			s.handleSetLootRule(player, actionData.Rule)
+		} else if actionData.Action == "equip" {
+			s.handleEquip(player, actionData.Slot)
+		} else if actionData.Action == "unequip" && actionData.Target != "" {
+			s.handleUnequip(player, actionData.Target, actionData.ToSlot)
+		} else if actionData.Action == "destroy_item" {
+			s.handleDestroyItem(player, actionData.Slot)
+		} else if actionData.Action == "move_item" {
+			s.handleMoveItem(player, actionData.Slot, actionData.ToSlot)
+		} else if actionData.Action == "use_item" && actionData.Target != "" {
+			s.handleUseItem(player, actionData.Target)
+		} else if actionData.Action == "party_invite" && actionData.Target != "" {
+			s.handlePartyInvite(player, actionData.Target)
+		} else if actionData.Action == "party_accept" {
+			s.handlePartyAccept(player)
+		} else if actionData.Action == "party_decline" {
+			s.handlePartyDecline(player)
+		} else if actionData.Action == "party_leave" {
+			s.handlePartyLeave(player)
+		} else if actionData.Action == "party_kick" && actionData.Target != "" {
+			s.handlePartyKick(player, actionData.Target)
+		} else if actionData.Action == "release" {
+			s.handleRelease(player)
+		} else if actionData.Action == "resurrect" && actionData.Target != "" {
+			s.handleResurrect(player, actionData.Target)
 		} else {
 			log.Printf("Player %s used action: %s", player.ID, actionData.Action)
 			s.broadcast <- types.Message{
@@ -250,11 +396,26 @@ func (s *GameServer) handleMessage(player *types.Player, msg types.Message) {
 func (s *GameServer) handleBroadcast() {
 	for msg := range s.broadcast {
 		s.mutex.RLock()
+
+		// Updates about a player only matter to the players sharing their zone
+		zone := msg.Zone
+		if sender, exists := s.players[msg.PlayerID]; exists && zone == "" && zoneScopedMessages[msg.Type] {
+			zone = sender.Zone
+		}
+
 		for _, player := range s.players {
 			if msg.Type == types.MsgPlayerMove && player.ID == msg.PlayerID {
 				continue
 			}
 
+			if msg.Recipients != nil && !slices.Contains(msg.Recipients, player.ID) {
+				continue
+			}
+
+			if zone != "" && player.Zone != zone {
+				continue
+			}
+
 			player.ConnMutex.Lock()
 			err := player.Conn.WriteJSON(msg)
 			player.ConnMutex.Unlock()
@@ -274,71 +435,89 @@ func (s *GameServer) GetConnectedPlayers() int {
 	return len(s.players)
 }
 
-func (s *GameServer) spawnInitialEnemies() {
-	for i := 0; i < 3; i++ {
-		enemyID := uuid.New().String()
-		enemy := &types.Enemy{
-			ID:         enemyID,
-			Name:       "Enemy " + enemyID[:8],
-			X:          200 + float64(i*300),
-			Y:          200 + float64(i*150),
-			EnemyType:  "basic",
-			Health:     50,
-			MaxHealth:  50,
-			TargetID:   "",
-			ThreatList: make(map[string]float64),
-			Weapon: &types.Weapon{
-				ID:         uuid.New().String(),
-				Name:       "Claws",
-				Damage:     10,
-				Range:      1,
-				WeaponType: "melee",
-				Delay:      2 * time.Second,
-			},
-		}
+// populateWorld places each zone's spawns when the first player joins. Whatever
+// the last players left behind is cleared first, so spawns are never doubled up.
+func (s *GameServer) populateWorld() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-		s.enemies[enemyID] = enemy
-		log.Printf("Spawned enemy %s at (%.0f, %.0f)", enemy.Name, enemy.X, enemy.Y)
+	s.clearEnemiesAndCorpses()
+	s.spawnInitialEnemies()
+}
 
-		s.broadcast <- types.Message{
-			Type: types.MsgEnemySpawn,
-			Data: s.marshal(enemy),
+// clearEnemiesAndCorpses removes every enemy and corpse from every zone
+func (s *GameServer) clearEnemiesAndCorpses() {
+	for _, enemy := range s.enemies {
+		s.despawnEnemy(enemy)
+	}
+	for _, corpse := range s.corpses {
+		s.removeCorpse(corpse)
+	}
+}
+
+// spawnInitialEnemies places every zone's spawns. It is only called while holding the mutex.
+func (s *GameServer) spawnInitialEnemies() {
+	for _, zoneID := range game.ZoneIDs() {
+		zone, _ := game.LookupZone(zoneID)
+		for _, spawn := range zone.Spawns() {
+			s.spawnEnemy(zoneID, spawn)
 		}
 	}
 }
 
-func (s *GameServer) handleRageDecay() {
-	ticker := time.NewTicker(2 * time.Second) // Check every 2 seconds
-	defer ticker.Stop()
+// spawnEnemy creates an enemy in a zone with its type's base stats and weapon, and
+// the spawn's idle behavior and pack
+func (s *GameServer) spawnEnemy(zone string, spawn game.EnemySpawn) *types.Enemy {
+	enemyType := spawn.EnemyType
+	enemyID := uuid.New().String()
+	baseStats := game.EnemyBaseStats(enemyType)
+	weapon := game.EnemyWeapon(enemyType)
+	weapon.ID = uuid.New().String()
+	template, _ := game.LookupEnemyTemplate(enemyType)
+	boss := template.Encounter != nil
+
+	// Bosses are unique, so they keep their name without an ID suffix
+	name := spawn.Name + " " + enemyID[:8]
+	if boss {
+		name = spawn.Name
+	}
+
+	enemy := &types.Enemy{
+		ID:         enemyID,
+		Name:       name,
+		X:          spawn.X,
+		Y:          spawn.Y,
+		Zone:       zone,
+		SpawnX:     spawn.X,
+		SpawnY:     spawn.Y,
+		EnemyType:  enemyType,
+		Level:      max(1, template.Level),
+		Boss:       boss,
+		TargetID:   "",
+		ThreatList: make(map[string]float64),
+		Cooldowns:  make(map[string]time.Time),
+		Strength:   baseStats.Strength,
+		Agility:    baseStats.Agility,
+		Intellect:  baseStats.Intellect,
+		Stamina:    baseStats.Stamina,
+		Armor:      baseStats.Armor,
+		Weapon:     &weapon,
+		Idle:       spawn.Idle,
+		PackID:     spawn.Pack,
+	}
+	s.refreshEnemyStats(enemy)
+	enemy.Health = enemy.MaxHealth
+
+	s.enemies[enemyID] = enemy
+	log.Printf("Spawned enemy %s in %s at (%.0f, %.0f)", enemy.Name, zone, enemy.X, enemy.Y)
 
-	for range ticker.C {
-		s.mutex.Lock()
-		
-		for _, player := range s.players {
-			if player.Class == "warrior" && player.Mana > 0 {
-				// Simple decay: lose 2 rage every tick (every 2 seconds)
-				rageDecay := 2
-				
-				oldRage := player.Mana
-				player.Mana -= rageDecay
-				
-				if player.Mana < 0 {
-					player.Mana = 0
-				}
-				
-				// Only broadcast if rage actually changed
-				if player.Mana != oldRage {
-					s.broadcast <- types.Message{
-						Type:     types.MsgPlayerUpdate,
-						PlayerID: player.ID,
-						Data:     s.marshal(player),
-					}
-				}
-			}
-		}
-		
-		s.mutex.Unlock()
+	s.broadcast <- types.Message{
+		Type: types.MsgEnemySpawn,
+		Data: s.marshal(enemy),
+		Zone: zone,
 	}
+
+	return enemy
 }
 
 func (s *GameServer) marshal(v interface{}) json.RawMessage {
@@ -354,6 +533,10 @@ func (s *GameServer) handleCriticalStrike(attacker *types.Player, targetEnemyID
 		log.Printf("Player %s (%s) attempted critical strike but is not a warrior", attacker.Name, attacker.Class)
 		return
 	}
+	if attacker.Dead {
+		log.Printf("Player %s attempted critical strike but is dead", attacker.Name)
+		return
+	}
 
 	rageCost := 30 // Critical strike costs 30 rage
 	if attacker.Mana < rageCost {
@@ -361,28 +544,13 @@ func (s *GameServer) handleCriticalStrike(attacker *types.Player, targetEnemyID
 		return
 	}
 
-	enemy, exists := s.enemies[targetEnemyID]
+	enemy, exists := s.zoneEnemy(attacker, targetEnemyID)
 	if !exists {
 		log.Printf("Critical Strike: Enemy %s not found", targetEnemyID)
 		return
 	}
 
-	attacker.Mana -= rageCost
-
-	// Calculate critical damage (2x normal damage + bonus)
-	baseDamage := 1
-	if attacker.Weapon != nil {
-		baseDamage = attacker.Weapon.Damage
-	}
-	critDamage := (baseDamage * 2) + 3 // 2x damage + 3 bonus
-
-	enemy.Health -= critDamage
-	log.Printf("Player %s used Critical Strike on %s for %d damage (HP: %d/%d)",
-		attacker.Name, enemy.Name, critDamage, enemy.Health, enemy.MaxHealth)
-
-	enemy.ThreatList[attacker.ID] += float64(critDamage)
-	
-	s.updateEnemyTarget(enemy)
+	s.spendResource(attacker, rageCost)
 
 	s.broadcast <- types.Message{
 		Type:     types.MsgPlayerUpdate,
@@ -390,21 +558,32 @@ func (s *GameServer) handleCriticalStrike(attacker *types.Player, targetEnemyID
 		Data:     s.marshal(attacker),
 	}
 
+	// Calculate critical damage (2x normal damage + bonus)
+	baseDamage := game.RollWeaponDamage(s.rng, attacker.Weapon, attacker.Derived.AttackPower)
+	critDamage := (baseDamage * 2) + 3 // 2x damage + 3 bonus
+
+	roll := game.RollSpecialAttack(s.rng, critDamage, attacker.Derived, enemy.Derived)
+	s.broadcastAttackEvent(attacker.ID, enemy.ID, "critical_strike", roll)
+
+	if !roll.Landed() {
+		log.Printf("Player %s used Critical Strike on %s but it was a %s", attacker.Name, enemy.Name, roll.HitType)
+		return
+	}
+
+	enemy.Health -= roll.Damage
+	log.Printf("Player %s used Critical Strike on %s for %d damage (%d mitigated) (HP: %d/%d)",
+		attacker.Name, enemy.Name, roll.Damage, roll.Mitigated, enemy.Health, enemy.MaxHealth)
+
+	s.addThreat(enemy, attacker.ID, "critical_strike", float64(roll.Damage))
+
 	if enemy.Health <= 0 {
-			delete(s.enemies, targetEnemyID)
 		log.Printf("Enemy %s has been defeated by %s's critical strike!", enemy.Name, attacker.Name)
-
-		s.broadcast <- types.Message{
-			Type: types.MsgEnemyUpdate,
-			Data: s.marshal(map[string]interface{}{
-				"id":   targetEnemyID,
-				"dead": true,
-			}),
-		}
+		s.killEnemy(enemy, attacker.ID)
 	} else {
 			s.broadcast <- types.Message{
 			Type: types.MsgEnemyUpdate,
 			Data: s.marshal(enemy),
+			Zone: enemy.Zone,
 		}
 	}
 }
@@ -413,73 +592,222 @@ func (s *GameServer) handleCombat(attacker *types.Player, targetEnemyID string)
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	enemy, exists := s.enemies[targetEnemyID]
+	if attacker.Dead {
+		log.Printf("Player %s attempted to attack but is dead", attacker.Name)
+		return
+	}
+
+	enemy, exists := s.zoneEnemy(attacker, targetEnemyID)
 	if !exists {
 		log.Printf("Combat: Enemy %s not found", targetEnemyID)
 		return
 	}
 
-	damage := 1 // Default damage
-	if attacker.Weapon != nil {
-		damage = attacker.Weapon.Damage
+	damage := game.RollWeaponDamage(s.rng, attacker.Weapon, attacker.Derived.AttackPower)
+
+	// Ranged weapons fire a projectile that resolves the attack when it lands
+	if game.IsRangedWeapon(attacker.Weapon) {
+		dx := enemy.X - attacker.X
+		dy := enemy.Y - attacker.Y
+		if math.Sqrt(dx*dx+dy*dy) > game.WeaponReach(attacker.Weapon)+rangedReachSlack {
+			log.Printf("Player %s attempted to shoot %s but is out of range", attacker.Name, enemy.Name)
+			return
+		}
+		if !s.hasLineOfSight(attacker.Zone, attacker.X, attacker.Y, enemy.X, enemy.Y) {
+			log.Printf("Player %s attempted to shoot %s without line of sight", attacker.Name, enemy.Name)
+			return
+		}
+
+		s.launchWeaponShot(attacker.ID, enemy.ID, attacker.Weapon, damage, attacker.Derived, enemy.Derived)
+		return
+	}
+
+	roll := game.RollMeleeAttack(s.rng, damage, attacker.Derived, enemy.Derived)
+	s.broadcastAttackEvent(attacker.ID, enemy.ID, "attack", roll)
+
+	if !roll.Landed() {
+		log.Printf("Player %s attacked %s but it was a %s", attacker.Name, enemy.Name, roll.HitType)
+		return
 	}
 
+	damage = roll.Damage
 	enemy.Health -= damage
-	log.Printf("Player %s attacked %s for %d damage (HP: %d/%d)",
-		attacker.Name, enemy.Name, damage, enemy.Health, enemy.MaxHealth)
+	log.Printf("Player %s attacked %s for %d damage (%s, %d mitigated) (HP: %d/%d)",
+		attacker.Name, enemy.Name, damage, roll.HitType, roll.Mitigated, enemy.Health, enemy.MaxHealth)
 
-	if attacker.Class == "warrior" {
-		rageGain := 5 // Base rage gained per attack
-		if attacker.Mana < attacker.MaxMana {
-			attacker.Mana = min(attacker.MaxMana, attacker.Mana + rageGain)
-			
-			s.broadcast <- types.Message{
-				Type:     types.MsgPlayerUpdate,
-				PlayerID: attacker.ID,
-				Data:     s.marshal(attacker),
-			}
+	if game.GainResource(attacker, game.ClassResource(attacker.Class).GainOnHit) {
+		s.broadcast <- types.Message{
+			Type:     types.MsgPlayerUpdate,
+			PlayerID: attacker.ID,
+			Data:     s.marshal(attacker),
 		}
 	}
 
-	enemy.ThreatList[attacker.ID] += float64(damage)
-	
-	s.updateEnemyTarget(enemy)
+	s.addThreat(enemy, attacker.ID, "attack", float64(damage))
 
 	if enemy.Health <= 0 {
-			delete(s.enemies, targetEnemyID)
 		log.Printf("Enemy %s has been defeated", enemy.Name)
-
-		// Broadcast enemy death
-		deathData := map[string]interface{}{
-			"id":   targetEnemyID,
-			"dead": true,
-		}
-		deathJSON, _ := json.Marshal(deathData)
-		s.broadcast <- types.Message{
-			Type: types.MsgEnemyUpdate,
-			Data: deathJSON,
-		}
+		s.killEnemy(enemy, attacker.ID)
 	} else {
 		// Enemy still alive - broadcast health update
 		s.broadcast <- types.Message{
 			Type: types.MsgEnemyUpdate,
 			Data: s.marshal(enemy),
+			Zone: enemy.Zone,
+		}
+	}
+}
+
+// broadcastCombatEvent reports a combat event to the players it is relevant to
+func (s *GameServer) broadcastCombatEvent(event types.CombatEvent) {
+	recipients := s.combatEventRecipients(event)
+	if len(recipients) == 0 {
+		return
+	}
+
+	s.broadcast <- types.Message{
+		Type:       types.MsgCombatEvent,
+		Data:       s.marshal(event),
+		Recipients: recipients,
+	}
+}
+
+// combatEventRecipients returns the players who should see a combat event: anyone
+// involved, anyone on an involved enemy's threat list and anyone close enough to watch
+func (s *GameServer) combatEventRecipients(event types.CombatEvent) []string {
+	combatLogRange := 500.0 // Pixels from the target within which events are visible
+
+	var recipients []string
+	for _, entityID := range []string{event.SourceID, event.TargetID} {
+		if _, exists := s.players[entityID]; exists && !slices.Contains(recipients, entityID) {
+			recipients = append(recipients, entityID)
+		}
+		if enemy, exists := s.enemies[entityID]; exists {
+			for playerID := range enemy.ThreatList {
+				if !slices.Contains(recipients, playerID) {
+					recipients = append(recipients, playerID)
+				}
+			}
+		}
+	}
+
+	targetX, targetY, zone, found := s.entityPosition(event.TargetID)
+	if !found {
+		return recipients
+	}
+
+	for _, player := range s.players {
+		if player.Zone != zone {
+			continue
+		}
+
+		dx := player.X - targetX
+		dy := player.Y - targetY
+		if math.Sqrt(dx*dx+dy*dy) <= combatLogRange && !slices.Contains(recipients, player.ID) {
+			recipients = append(recipients, player.ID)
+		}
+	}
+
+	return recipients
+}
+
+// entityPosition returns the position and zone of a player or enemy by ID
+func (s *GameServer) entityPosition(entityID string) (x, y float64, zone string, found bool) {
+	if player, exists := s.players[entityID]; exists {
+		return player.X, player.Y, player.Zone, true
+	}
+	if enemy, exists := s.enemies[entityID]; exists {
+		return enemy.X, enemy.Y, enemy.Zone, true
+	}
+	return 0, 0, "", false
+}
+
+// broadcastAttackEvent reports the outcome of an attack roll
+func (s *GameServer) broadcastAttackEvent(sourceID, targetID, ability string, roll game.AttackRoll) {
+	s.broadcastCombatEvent(types.CombatEvent{
+		Kind:      types.CombatDamage,
+		SourceID:  sourceID,
+		TargetID:  targetID,
+		Ability:   ability,
+		HitType:   roll.HitType,
+		Amount:    roll.Damage,
+		Mitigated: roll.Mitigated,
+	})
+}
+
+// killEnemy removes a defeated enemy from the world and notifies all clients
+func (s *GameServer) killEnemy(enemy *types.Enemy, killerID string) {
+	s.broadcastCombatEvent(types.CombatEvent{
+		Kind:     types.CombatDeath,
+		SourceID: killerID,
+		TargetID: enemy.ID,
+	})
+
+	s.spawnCorpse(enemy, killerID)
+	s.awardKillXP(enemy, killerID)
+	s.despawnEnemy(enemy)
+}
+
+// despawnEnemy removes an enemy from the world without it dying
+func (s *GameServer) despawnEnemy(enemy *types.Enemy) {
+	delete(s.enemies, enemy.ID)
+
+	s.broadcast <- types.Message{
+		Type: types.MsgEnemyUpdate,
+		Data: s.marshal(map[string]interface{}{
+			"id":   enemy.ID,
+			"dead": true,
+		}),
+		Zone: enemy.Zone,
+	}
+}
+
+// killPlayer marks a player as dead and removes them from every enemy's threat list
+func (s *GameServer) killPlayer(player *types.Player, killerID string) {
+	s.broadcastCombatEvent(types.CombatEvent{
+		Kind:     types.CombatDeath,
+		SourceID: killerID,
+		TargetID: player.ID,
+	})
+
+	player.Health = 0
+	player.Dead = true
+	player.Auras = nil
+	s.refreshPlayerStats(player)
+	s.dropFromThreatLists(player)
+
+	// Send death message to all players
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: player.ID,
+		Data:     s.marshal(player),
+	}
+}
+
+// dropFromThreatLists takes a player off every enemy's threat list, for when they
+// die or leave the zone
+func (s *GameServer) dropFromThreatLists(player *types.Player) {
+	for _, enemy := range s.enemies {
+		delete(enemy.ThreatList, player.ID)
+		if enemy.TargetID == player.ID {
+			enemy.TargetID = ""
+			s.updateEnemyTarget(enemy) // Try to find new target
 		}
 	}
 }
 
 // updateEnemyTarget selects the player with highest threat as the new target
 func (s *GameServer) updateEnemyTarget(enemy *types.Enemy) {
-	var highestThreat float64
 	var newTargetID string
-	
-	// Find player with highest threat that still exists and is alive
-	for playerID, threat := range enemy.ThreatList {
-		if player, exists := s.players[playerID]; exists && !player.Dead && threat > highestThreat {
-			highestThreat = threat
-			newTargetID = playerID
-		}
+
+	// A taunt forces the enemy onto the taunter until it wears off
+	if taunter, exists := s.players[enemy.TauntedBy]; exists && !taunter.Dead && taunter.Zone == enemy.Zone && time.Now().Before(enemy.TauntEnds) {
+		newTargetID = taunter.ID
+	} else {
+		enemy.TauntedBy = ""
+		newTargetID = game.SelectThreatTarget(enemy.TargetID, s.threatCandidates(enemy))
 	}
+	highestThreat := enemy.ThreatList[newTargetID]
 	
 	if newTargetID != enemy.TargetID {
 		oldTarget := enemy.TargetID
@@ -491,22 +819,84 @@ func (s *GameServer) updateEnemyTarget(enemy *types.Enemy) {
 		} else if newTargetID != "" {
 			log.Printf("Enemy %s now targeting %s (threat: %.1f)", 
 				enemy.Name, newTargetID[:8], highestThreat)
+			s.pullPack(enemy)
+		}
+
+		if newTargetID != "" {
+			s.broadcastCombatEvent(types.CombatEvent{
+				Kind:     types.CombatThreat,
+				SourceID: enemy.ID,
+				TargetID: newTargetID,
+			})
 		}
 	}
 }
 
+// idleMoveBroadcastTicks is how many AI ticks pass between sending the positions
+// of enemies that are moving out of combat
+const idleMoveBroadcastTicks = 5
+
 func (s *GameServer) handleEnemyAI() {
 	ticker := time.NewTicker(100 * time.Millisecond) // Update AI 10 times per second
 	defer ticker.Stop()
 
-	for range ticker.C {
+	// Enemies strolling out of combat only have their position sent every few ticks
+	unsentIdleMoves := make(map[string]bool) // Enemy ID -> moved since its position was last sent
+	for tick := 1; ; tick++ {
+		<-ticker.C
+		sendIdleMoves := tick%idleMoveBroadcastTicks == 0
+
 		s.mutex.Lock()
-		
+
+		moves := make(map[string][]types.EnemyPosition) // Zone ID -> enemies that moved in it
 		for _, enemy := range s.enemies {
+			x, y, state := enemy.X, enemy.Y, enemy.AIState
 			s.processEnemyAI(enemy)
+
+			if enemy.AIState != state {
+				// State changes can come with other changes, such as auras cleared on evading.
+				// The full update is sent under the lock so it cannot overtake a newer one.
+				delete(unsentIdleMoves, enemy.ID)
+				s.broadcast <- types.Message{
+					Type: types.MsgEnemyUpdate,
+					Data: s.marshal(enemy),
+					Zone: enemy.Zone,
+				}
+				continue
+			}
+
+			moved := enemy.X != x || enemy.Y != y
+			resting := enemy.AIState == types.AIIdle || enemy.AIState == types.AIPatrol
+			if moved && resting && !sendIdleMoves {
+				unsentIdleMoves[enemy.ID] = true
+				continue
+			}
+			if moved || (sendIdleMoves && unsentIdleMoves[enemy.ID]) {
+				delete(unsentIdleMoves, enemy.ID)
+				moves[enemy.Zone] = append(moves[enemy.Zone], types.EnemyPosition{ID: enemy.ID, X: enemy.X, Y: enemy.Y})
+			}
 		}
-		
+		if sendIdleMoves {
+			for enemyID := range unsentIdleMoves {
+				if _, exists := s.enemies[enemyID]; !exists {
+					delete(unsentIdleMoves, enemyID)
+				}
+			}
+		}
+
+		s.landGroundEffects(time.Now())
+
 		s.mutex.Unlock()
+
+		// Moves go out as one message per zone, sent after unlocking so a full
+		// broadcast queue cannot hold up everything else that needs the mutex
+		for zoneID, positions := range moves {
+			s.broadcast <- types.Message{
+				Type: types.MsgEnemyPositions,
+				Data: s.marshal(positions),
+				Zone: zoneID,
+			}
+		}
 	}
 }
 
@@ -517,81 +907,38 @@ func (s *GameServer) processEnemyAI(enemy *types.Enemy) {
 			delete(enemy.ThreatList, playerID)
 		}
 	}
-	
-	s.addRangeThreat(enemy)
-	
-	if enemy.TargetID == "" {
-		s.findNearbyTarget(enemy)
-	} else {
-		target, exists := s.players[enemy.TargetID]
-		if !exists {
-			enemy.TargetID = ""
-			s.updateEnemyTarget(enemy) // Try to find new target from threat list
-			return
-		}
-
-		dx := target.X - enemy.X
-		dy := target.Y - enemy.Y
-		distance := math.Sqrt(dx*dx + dy*dy)
 
-		weaponRange := 30.0 // Default range
-		if enemy.Weapon != nil {
-			weaponRange = float64(enemy.Weapon.Range * 25) // Scale range like client
-		}
-
-		if distance > weaponRange {
-			s.moveEnemyTowardTarget(enemy, target, distance, dx, dy)
-		} else {
-			s.attemptEnemyAttack(enemy, target)
-		}
+	// Re-evaluate once a taunt wears off, even if nobody gained threat this tick
+	if enemy.TauntedBy != "" && !time.Now().Before(enemy.TauntEnds) {
+		s.updateEnemyTarget(enemy)
 	}
+
+	game.RunAI(aiWorld{s: s, now: time.Now()}, enemy)
 }
 
-func (s *GameServer) addRangeThreat(enemy *types.Enemy) {
-	aggroRange := 100.0 // Aggro range in pixels
-	rangeThreat := 0.1   // Small threat per tick for being in range
-	
-	for _, player := range s.players {
-		// Skip dead players
-		if player.Dead {
-			continue
-		}
-		
-		dx := player.X - enemy.X
-		dy := player.Y - enemy.Y
-		distance := math.Sqrt(dx*dx + dy*dy)
-		
-		if distance <= aggroRange {
-			enemy.ThreatList[player.ID] += rangeThreat
-			
-			s.updateEnemyTarget(enemy)
-		}
+// moveEnemyToward steps an enemy toward a point, sliding along walls. It returns
+// false if a wall kept the enemy from moving at all.
+func (s *GameServer) moveEnemyToward(enemy *types.Enemy, x, y, speed float64) bool {
+	dx := x - enemy.X
+	dy := y - enemy.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance == 0 {
+		return true
 	}
-}
 
-func (s *GameServer) findNearbyTarget(enemy *types.Enemy) {
-	s.updateEnemyTarget(enemy)
-}
+	step := math.Min(speed, distance)
+	newX := enemy.X + (dx/distance)*step
+	newY := enemy.Y + (dy/distance)*step
 
-func (s *GameServer) moveEnemyTowardTarget(enemy *types.Enemy, target *types.Player, distance, dx, dy float64) {
-	moveSpeed := 2.0 // Enemy move speed
-	
-	if distance > 0 {
-		newX := enemy.X + (dx/distance)*moveSpeed
-		newY := enemy.Y + (dy/distance)*moveSpeed
-		
-		validX, validY := game.CheckWallCollisionWithSliding(enemy.X, enemy.Y, newX, newY, s.room.Walls)
-		
-		if validX != enemy.X || validY != enemy.Y {
-			enemy.X = validX
-			enemy.Y = validY
-			
-			s.broadcast <- types.Message{
-				Type: types.MsgEnemyUpdate,
-				Data: s.marshal(enemy),
-			}
-		}
+	validX, validY := game.CheckWallCollisionWithSliding(enemy.X, enemy.Y, newX, newY, s.rooms[enemy.Zone].Walls)
+	if validX == enemy.X && validY == enemy.Y {
+		return false
 	}
+
+	// Clients hear about the move from the AI tick's batch of enemy positions
+	enemy.X = validX
+	enemy.Y = validY
+	return true
 }
 
 func (s *GameServer) attemptEnemyAttack(enemy *types.Enemy, target *types.Player) {
@@ -608,43 +955,49 @@ func (s *GameServer) attemptEnemyAttack(enemy *types.Enemy, target *types.Player
 	if time.Since(enemy.LastAttack) > weaponDelay {
 		damage := 2 // Default damage
 		if enemy.Weapon != nil {
-			damage = enemy.Weapon.Damage
+			damage = game.RollWeaponDamage(s.rng, enemy.Weapon, enemy.Derived.AttackPower)
 		}
-		
-		target.Health -= damage
+
 		enemy.LastAttack = time.Now()
-		
-		if target.Class == "warrior" {
-			rageGain := 3 // Base rage gained per hit taken
-			if target.Mana < target.MaxMana {
-				target.Mana = min(target.MaxMana, target.Mana + rageGain)
-			}
-		}
-		
-		log.Printf("Enemy %s attacked player %s for %d damage (HP: %d/%d)",
-			enemy.Name, target.Name, damage, target.Health, target.MaxHealth)
-		
-		s.broadcast <- types.Message{
-			Type:     types.MsgPlayerUpdate,
-			PlayerID: target.ID,
-			Data:     s.marshal(target),
-		}
-		
-		if target.Health <= 0 {
-			target.Health = 0
-			target.Dead = true
-			delete(enemy.ThreatList, target.ID)
-			enemy.TargetID = ""
-			s.updateEnemyTarget(enemy) // Try to find new target
-			log.Printf("Player %s has been defeated by %s", target.Name, enemy.Name)
-			
-			// Send death message to all players
-			s.broadcast <- types.Message{
-				Type:     types.MsgPlayerUpdate,
-				PlayerID: target.ID,
-				Data:     s.marshal(target),
-			}
+
+		if game.IsRangedWeapon(enemy.Weapon) {
+			s.launchWeaponShot(enemy.ID, target.ID, enemy.Weapon, damage, enemy.Derived, target.Derived)
+			return
 		}
+
+		roll := game.RollMeleeAttack(s.rng, damage, enemy.Derived, target.Derived)
+		s.applyEnemyDamage(enemy, target, "attack", roll)
+	}
+}
+
+// applyEnemyDamage deals a rolled enemy attack to a player
+func (s *GameServer) applyEnemyDamage(enemy *types.Enemy, target *types.Player, ability string, roll game.AttackRoll) {
+	s.broadcastAttackEvent(enemy.ID, target.ID, ability, roll)
+
+	if !roll.Landed() {
+		log.Printf("Enemy %s attacked player %s but it was a %s", enemy.Name, target.Name, roll.HitType)
+		return
+	}
+
+	target.Health -= roll.Damage
+
+	// Only melee blows generate rage
+	if ability == "attack" {
+		game.GainResource(target, game.ClassResource(target.Class).GainOnHitTaken)
+	}
+
+	log.Printf("Enemy %s attacked player %s for %d damage (%s, %d mitigated) (HP: %d/%d)",
+		enemy.Name, target.Name, roll.Damage, roll.HitType, roll.Mitigated, target.Health, target.MaxHealth)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: target.ID,
+		Data:     s.marshal(target),
+	}
+
+	if target.Health <= 0 {
+		log.Printf("Player %s has been defeated by %s", target.Name, enemy.Name)
+		s.killPlayer(target, enemy.ID)
 	}
 }
 
@@ -0,0 +1,145 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	frostboltManaCost = 30
+	frostboltRange    = 300.0 // Pixels
+	frostboltSpeed    = 300.0 // Pixels per second
+	frostNovaManaCost = 40
+	frostNovaRadius   = 100.0 // Pixels around the caster
+	frostNovaCooldown = 10 * time.Second
+	frostNovaTargets  = 5 // Most enemies a single nova can hit
+)
+
+// isOnCooldown reports whether a player's ability is still recharging
+func (s *GameServer) isOnCooldown(player *types.Player, ability string) bool {
+	return player.Cooldowns[ability] > time.Now().UnixMilli()
+}
+
+// startCooldown marks an ability as unavailable for the given duration
+func (s *GameServer) startCooldown(player *types.Player, ability string, duration time.Duration) {
+	if player.Cooldowns == nil {
+		player.Cooldowns = make(map[string]int64)
+	}
+	player.Cooldowns[ability] = time.Now().Add(duration).UnixMilli()
+}
+
+func (s *GameServer) handleFrostbolt(caster *types.Player, targetEnemyID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if caster.Class != "mage" {
+		log.Printf("Player %s (%s) attempted frostbolt but is not a mage", caster.Name, caster.Class)
+		return
+	}
+	if caster.Dead {
+		log.Printf("Player %s attempted frostbolt but is dead", caster.Name)
+		return
+	}
+
+	if caster.Mana < frostboltManaCost {
+		log.Printf("Player %s attempted frostbolt but lacks mana (%d/%d)", caster.Name, caster.Mana, frostboltManaCost)
+		return
+	}
+
+	enemy, exists := s.zoneEnemy(caster, targetEnemyID)
+	if !exists {
+		log.Printf("Frostbolt: Enemy %s not found", targetEnemyID)
+		return
+	}
+
+	dx := enemy.X - caster.X
+	dy := enemy.Y - caster.Y
+	if math.Sqrt(dx*dx+dy*dy) > frostboltRange {
+		log.Printf("Player %s attempted frostbolt on %s but is out of range", caster.Name, enemy.Name)
+		return
+	}
+
+	s.spendResource(caster, frostboltManaCost)
+
+	damage := game.RollSpellDamage(s.rng, 14, 18, caster.Derived.SpellPower, 0.8)
+	roll := game.RollSpellAttack(s.rng, damage, caster.Derived, enemy.Derived)
+	s.launchProjectile(caster.ID, enemy.ID, "frostbolt", "frostbolt", frostboltSpeed, roll)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: caster.ID,
+		Data:     s.marshal(caster),
+	}
+}
+
+func (s *GameServer) handleFrostNova(caster *types.Player) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if caster.Class != "mage" {
+		log.Printf("Player %s (%s) attempted frost nova but is not a mage", caster.Name, caster.Class)
+		return
+	}
+	if caster.Dead {
+		log.Printf("Player %s attempted frost nova but is dead", caster.Name)
+		return
+	}
+
+	if s.isOnCooldown(caster, "frost_nova") {
+		log.Printf("Player %s attempted frost nova but it is on cooldown", caster.Name)
+		return
+	}
+
+	if caster.Mana < frostNovaManaCost {
+		log.Printf("Player %s attempted frost nova but lacks mana (%d/%d)", caster.Name, caster.Mana, frostNovaManaCost)
+		return
+	}
+
+	s.spendResource(caster, frostNovaManaCost)
+	s.startCooldown(caster, "frost_nova", frostNovaCooldown)
+	log.Printf("Player %s cast Frost Nova", caster.Name)
+
+	area := game.Area{Shape: game.AreaCircle, X: caster.X, Y: caster.Y, Radius: frostNovaRadius}
+	for _, enemy := range s.enemiesInArea(caster.Zone, area, frostNovaTargets) {
+		damage := game.RollSpellDamage(s.rng, 8, 12, caster.Derived.SpellPower, 0.2)
+		roll := game.RollSpellAttack(s.rng, damage, caster.Derived, enemy.Derived)
+		s.applySpellDamage(caster, enemy, "frost_nova", roll)
+	}
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: caster.ID,
+		Data:     s.marshal(caster),
+	}
+}
+
+// applySpellDamage deals a rolled spell hit to an enemy, generating threat for the caster
+func (s *GameServer) applySpellDamage(caster *types.Player, enemy *types.Enemy, ability string, roll game.AttackRoll) {
+	s.broadcastAttackEvent(caster.ID, enemy.ID, ability, roll)
+
+	if !roll.Landed() {
+		log.Printf("Player %s's %s missed %s", caster.Name, ability, enemy.Name)
+		return
+	}
+
+	enemy.Health -= roll.Damage
+	log.Printf("Player %s's %s hit %s for %d damage (%s) (HP: %d/%d)",
+		caster.Name, ability, enemy.Name, roll.Damage, roll.HitType, enemy.Health, enemy.MaxHealth)
+
+	s.addThreat(enemy, caster.ID, ability, float64(roll.Damage))
+
+	if enemy.Health <= 0 {
+		log.Printf("Enemy %s has been defeated by %s's %s", enemy.Name, caster.Name, ability)
+		s.killEnemy(enemy, caster.ID)
+	} else {
+		s.broadcast <- types.Message{
+			Type: types.MsgEnemyUpdate,
+			Data: s.marshal(enemy),
+			Zone: enemy.Zone,
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package networking
+
+import (
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// refreshPlayerStats recalculates a player's derived stats from their level, attributes, gear and auras.
+// Gaining max health also raises current health by the same amount.
+func (s *GameServer) refreshPlayerStats(player *types.Player) {
+	player.Derived = game.DeriveStats(game.EffectivePlayerStats(player))
+	player.Derived.Level = player.Level
+
+	if gained := player.Derived.MaxHealth - player.MaxHealth; gained > 0 && !player.Dead {
+		player.Health += gained
+	}
+	player.MaxHealth = player.Derived.MaxHealth
+	player.Health = min(player.Health, player.MaxHealth)
+
+	player.MaxMana = game.MaxResource(game.ClassResource(player.Class), player.Derived)
+	player.Mana = min(player.Mana, player.MaxMana)
+}
+
+// refreshEnemyStats recalculates an enemy's derived stats from its attributes and auras
+func (s *GameServer) refreshEnemyStats(enemy *types.Enemy) {
+	enemy.Derived = game.DeriveStats(game.EffectiveEnemyStats(enemy))
+	enemy.Derived.Level = enemy.Level
+
+	if gained := enemy.Derived.MaxHealth - enemy.MaxHealth; gained > 0 {
+		enemy.Health += gained
+	}
+	enemy.MaxHealth = enemy.Derived.MaxHealth
+	enemy.Health = min(enemy.Health, enemy.MaxHealth)
+}
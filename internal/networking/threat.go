@@ -0,0 +1,229 @@
+package networking
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+const (
+	meleeThreatRange = 40.0 // Pixels within which a player counts as melee for the overtake rule
+	tauntRange       = 150.0
+	tauntDuration    = 3 * time.Second
+	tauntCooldown    = 8 * time.Second
+	feintEnergyCost  = 20
+	feintReduction   = 0.5 // Fraction of threat on the target removed by Feint
+	feintCooldown    = 10 * time.Second
+	fadeManaCost     = 20
+	fadeReduction    = 0.3 // Fraction of threat on every enemy removed by Fade
+	fadeCooldown     = 30 * time.Second
+	threatTableSize  = 5 // Entries sent to the client threat meter
+)
+
+// addThreat credits a player with threat from an ability and re-evaluates the enemy's target
+func (s *GameServer) addThreat(enemy *types.Enemy, playerID, ability string, amount float64) {
+	if enemy.AIState == types.AIEvade {
+		return // Evading enemies ignore attackers until they are home
+	}
+
+	enemy.ThreatList[playerID] += game.AbilityThreat(ability, amount)
+	s.updateEnemyTarget(enemy)
+}
+
+// threatCandidates lists the living players on an enemy's threat list
+func (s *GameServer) threatCandidates(enemy *types.Enemy) []game.ThreatCandidate {
+	var candidates []game.ThreatCandidate
+	for playerID, threat := range enemy.ThreatList {
+		if player, exists := s.players[playerID]; exists && !player.Dead {
+			candidates = append(candidates, game.ThreatCandidate{
+				ID:      playerID,
+				Threat:  threat,
+				InMelee: s.inMeleeRange(enemy, player),
+			})
+		}
+	}
+	return candidates
+}
+
+// handleThreatUpdates sends each enemy's threat table to the players on it for their threat meters
+func (s *GameServer) handleThreatUpdates() {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mutex.Lock()
+
+		for _, enemy := range s.enemies {
+			candidates := s.threatCandidates(enemy)
+			if len(candidates) == 0 {
+				continue
+			}
+
+			recipients := make([]string, 0, len(candidates))
+			for _, candidate := range candidates {
+				recipients = append(recipients, candidate.ID)
+			}
+
+			s.broadcast <- types.Message{
+				Type:       types.MsgThreatUpdate,
+				Data:       s.marshal(game.BuildThreatTable(enemy.ID, enemy.TargetID, candidates, threatTableSize)),
+				Recipients: recipients,
+			}
+		}
+
+		s.mutex.Unlock()
+	}
+}
+
+// inCombat reports whether any enemy has the player on its threat list
+func (s *GameServer) inCombat(player *types.Player) bool {
+	for _, enemy := range s.enemies {
+		if _, listed := enemy.ThreatList[player.ID]; listed {
+			return true
+		}
+	}
+	return false
+}
+
+// inMeleeRange reports whether a player counts as melee for the aggro overtake rule
+func (s *GameServer) inMeleeRange(enemy *types.Enemy, player *types.Player) bool {
+	dx := player.X - enemy.X
+	dy := player.Y - enemy.Y
+	return math.Sqrt(dx*dx+dy*dy) <= meleeThreatRange
+}
+
+// handleTaunt forces an enemy to attack the warrior and raises their threat to match the top of the list
+func (s *GameServer) handleTaunt(player *types.Player, targetEnemyID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if player.Class != "warrior" {
+		log.Printf("Player %s (%s) attempted taunt but is not a warrior", player.Name, player.Class)
+		return
+	}
+	if player.Dead {
+		log.Printf("Player %s attempted taunt but is dead", player.Name)
+		return
+	}
+
+	if s.isOnCooldown(player, "taunt") {
+		log.Printf("Player %s attempted taunt but it is on cooldown", player.Name)
+		return
+	}
+
+	enemy, exists := s.zoneEnemy(player, targetEnemyID)
+	if !exists {
+		log.Printf("Taunt: Enemy %s not found", targetEnemyID)
+		return
+	}
+
+	dx := enemy.X - player.X
+	dy := enemy.Y - player.Y
+	if math.Sqrt(dx*dx+dy*dy) > tauntRange {
+		log.Printf("Player %s attempted taunt on %s but is out of range", player.Name, enemy.Name)
+		return
+	}
+
+	s.startCooldown(player, "taunt", tauntCooldown)
+
+	enemy.ThreatList[player.ID] = max(enemy.ThreatList[player.ID], game.TopThreat(enemy.ThreatList))
+	enemy.TauntedBy = player.ID
+	enemy.TauntEnds = time.Now().Add(tauntDuration)
+	log.Printf("Player %s taunted %s (threat: %.1f)", player.Name, enemy.Name, enemy.ThreatList[player.ID])
+
+	s.updateEnemyTarget(enemy)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: player.ID,
+		Data:     s.marshal(player),
+	}
+}
+
+// handleFeint lowers the rogue's threat on their target
+func (s *GameServer) handleFeint(player *types.Player, targetEnemyID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if player.Class != "rogue" {
+		log.Printf("Player %s (%s) attempted feint but is not a rogue", player.Name, player.Class)
+		return
+	}
+	if player.Dead {
+		log.Printf("Player %s attempted feint but is dead", player.Name)
+		return
+	}
+
+	if s.isOnCooldown(player, "feint") {
+		log.Printf("Player %s attempted feint but it is on cooldown", player.Name)
+		return
+	}
+
+	if player.Mana < feintEnergyCost {
+		log.Printf("Player %s attempted feint but lacks energy (%d/%d)", player.Name, player.Mana, feintEnergyCost)
+		return
+	}
+
+	enemy, exists := s.zoneEnemy(player, targetEnemyID)
+	if !exists {
+		log.Printf("Feint: Enemy %s not found", targetEnemyID)
+		return
+	}
+
+	s.spendResource(player, feintEnergyCost)
+	s.startCooldown(player, "feint", feintCooldown)
+
+	removed := game.ReduceThreat(enemy.ThreatList, player.ID, feintReduction)
+	log.Printf("Player %s feinted %s, removing %.1f threat", player.Name, enemy.Name, removed)
+	s.updateEnemyTarget(enemy)
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: player.ID,
+		Data:     s.marshal(player),
+	}
+}
+
+// handleFade lowers the priest's threat on every enemy
+func (s *GameServer) handleFade(player *types.Player) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if player.Class != "priest" {
+		log.Printf("Player %s (%s) attempted fade but is not a priest", player.Name, player.Class)
+		return
+	}
+	if player.Dead {
+		log.Printf("Player %s attempted fade but is dead", player.Name)
+		return
+	}
+
+	if s.isOnCooldown(player, "fade") {
+		log.Printf("Player %s attempted fade but it is on cooldown", player.Name)
+		return
+	}
+
+	if player.Mana < fadeManaCost {
+		log.Printf("Player %s attempted fade but lacks mana (%d/%d)", player.Name, player.Mana, fadeManaCost)
+		return
+	}
+
+	s.spendResource(player, fadeManaCost)
+	s.startCooldown(player, "fade", fadeCooldown)
+	log.Printf("Player %s cast Fade", player.Name)
+
+	for _, enemy := range s.enemies {
+		if game.ReduceThreat(enemy.ThreatList, player.ID, fadeReduction) > 0 {
+			s.updateEnemyTarget(enemy)
+		}
+	}
+
+	s.broadcast <- types.Message{
+		Type:     types.MsgPlayerUpdate,
+		PlayerID: player.ID,
+		Data:     s.marshal(player),
+	}
+}
@@ -0,0 +1,128 @@
+package networking
+
+import (
+	"log"
+
+	"github.com/CollinEMac/tarnation/internal/game"
+	"github.com/CollinEMac/tarnation/internal/types"
+)
+
+// zoneScopedMessages are about the player in the message's PlayerID, so they are
+// only delivered to players in that player's zone
+var zoneScopedMessages = map[types.MessageType]bool{
+	types.MsgPlayerJoin:     true,
+	types.MsgPlayerMove:     true,
+	types.MsgPlayerUpdate:   true,
+	types.MsgPlayerTeleport: true,
+	types.MsgPlayerAction:   true,
+}
+
+// createZoneRooms builds the map of every zone in the world
+func createZoneRooms() map[string]types.Room {
+	rooms := make(map[string]types.Room)
+	for _, zoneID := range game.ZoneIDs() {
+		zone, _ := game.LookupZone(zoneID)
+		rooms[zoneID] = zone.Room()
+	}
+	return rooms
+}
+
+// zoneEnemy looks up an enemy a player is acting on. Enemies in other zones
+// cannot be reached, so they are treated as missing.
+func (s *GameServer) zoneEnemy(player *types.Player, enemyID string) (*types.Enemy, bool) {
+	enemy, exists := s.enemies[enemyID]
+	if !exists || enemy.Zone != player.Zone {
+		return nil, false
+	}
+	return enemy, true
+}
+
+// zonePlayerIDs returns the IDs of the players in a zone, leaving out one player.
+// The result is never nil, so it can be used as message recipients.
+func (s *GameServer) zonePlayerIDs(zoneID, exceptID string) []string {
+	ids := []string{}
+	for _, player := range s.players {
+		if player.Zone == zoneID && player.ID != exceptID {
+			ids = append(ids, player.ID)
+		}
+	}
+	return ids
+}
+
+// changeZone moves a player into another zone at the given position. The players
+// left behind see them leave, the player gets the new zone's map and everything in
+// it, and the players already there see them arrive.
+func (s *GameServer) changeZone(player *types.Player, zoneID string, x, y float64) {
+	room, exists := s.rooms[zoneID]
+	if !exists {
+		log.Printf("Player %s attempted to enter unknown zone %s", player.Name, zoneID)
+		return
+	}
+
+	oldZone := player.Zone
+	s.broadcast <- types.Message{
+		Type:       types.MsgPlayerLeave,
+		PlayerID:   player.ID,
+		Recipients: s.zonePlayerIDs(oldZone, player.ID),
+	}
+
+	player.Zone = zoneID
+	player.X = x
+	player.Y = y
+	s.dropFromThreatLists(player) // After the move, so taunts from the old zone are let go too
+	log.Printf("Player %s moved from %s to %s", player.Name, oldZone, zoneID)
+
+	s.broadcast <- types.Message{
+		Type:       types.MsgZoneChange,
+		PlayerID:   player.ID,
+		Data:       s.marshal(types.ZoneChange{Room: room, X: x, Y: y}),
+		Recipients: []string{player.ID},
+	}
+	s.sendZoneContents(player)
+
+	s.broadcast <- types.Message{
+		Type:       types.MsgPlayerJoin,
+		PlayerID:   player.ID,
+		Data:       s.marshal(player),
+		Recipients: s.zonePlayerIDs(zoneID, player.ID),
+	}
+}
+
+// sendZoneContents sends a player everything in their zone: the other players, the enemies and the corpses
+func (s *GameServer) sendZoneContents(player *types.Player) {
+	recipient := []string{player.ID}
+
+	for _, other := range s.players {
+		if other.ID == player.ID || other.Zone != player.Zone {
+			continue
+		}
+		s.broadcast <- types.Message{
+			Type:       types.MsgPlayerJoin,
+			PlayerID:   other.ID,
+			Data:       s.marshal(other),
+			Recipients: recipient,
+		}
+	}
+
+	for _, enemy := range s.enemies {
+		if enemy.Zone != player.Zone {
+			continue
+		}
+		s.broadcast <- types.Message{
+			Type:       types.MsgEnemySpawn,
+			Data:       s.marshal(enemy),
+			Recipients: recipient,
+		}
+	}
+
+	for _, corpse := range s.corpses {
+		if corpse.Zone != player.Zone {
+			continue
+		}
+		s.broadcast <- types.Message{
+			Type:       types.MsgCorpseSpawn,
+			Data:       s.marshal(corpse),
+			Recipients: recipient,
+		}
+	}
+}
@@ -12,46 +12,89 @@ import (
 type MessageType string
 
 const (
-	MsgPlayerJoin   MessageType = "player_join"
-	MsgPlayerLeave  MessageType = "player_leave"
-	MsgPlayerMove   MessageType = "player_move"
-	MsgPlayerUpdate MessageType = "player_update"
-	MsgPlayerAction MessageType = "player_action"
-	MsgGameState    MessageType = "game_state"
-	MsgEnemySpawn   MessageType = "enemy_spawn"
-	MsgEnemyUpdate  MessageType = "enemy_update"
-	MsgRoomData     MessageType = "room_data"
-	MsgError        MessageType = "error"
+	MsgPlayerJoin       MessageType = "player_join"
+	MsgPlayerLeave      MessageType = "player_leave"
+	MsgPlayerMove       MessageType = "player_move"
+	MsgPlayerUpdate     MessageType = "player_update"
+	MsgPlayerAction     MessageType = "player_action"
+	MsgGameState        MessageType = "game_state"
+	MsgEnemySpawn       MessageType = "enemy_spawn"
+	MsgEnemyUpdate      MessageType = "enemy_update"
+	MsgRoomData         MessageType = "room_data"
+	MsgCombatEvent      MessageType = "combat_event"
+	MsgPlayerTeleport   MessageType = "player_teleport"
+	MsgCharacterCreate  MessageType = "character_create"
+	MsgProjectileSpawn  MessageType = "projectile_spawn"
+	MsgProjectileRemove MessageType = "projectile_remove"
+	MsgThreatUpdate     MessageType = "threat_update"
+	MsgGroundEffect     MessageType = "ground_effect"
+	MsgEnemyYell        MessageType = "enemy_yell"
+	MsgCorpseSpawn      MessageType = "corpse_spawn"
+	MsgCorpseRemove     MessageType = "corpse_remove"
+	MsgLootWindow       MessageType = "loot_window"
+	MsgLootRule         MessageType = "loot_rule"
+	MsgXPGain           MessageType = "xp_gain"
+	MsgLevelUp          MessageType = "level_up"
+	MsgPartyInvite      MessageType = "party_invite"
+	MsgPartyUpdate      MessageType = "party_update"
+	MsgChat             MessageType = "chat"
+	MsgNotice           MessageType = "notice"
+	MsgAdminCommand     MessageType = "admin_command"
+	MsgAnnouncement     MessageType = "announcement"
+	MsgZoneChange       MessageType = "zone_change"
+	MsgEnemyPositions   MessageType = "enemy_positions"
+	MsgError            MessageType = "error"
 )
 
 // Message represents all communication between client and server
 type Message struct {
-	Type      MessageType     `json:"type"`
-	PlayerID  string          `json:"player_id,omitempty"`
-	Data      json.RawMessage `json:"data,omitempty"`
-	Timestamp int64           `json:"timestamp"`
+	Type       MessageType     `json:"type"`
+	PlayerID   string          `json:"player_id,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	Timestamp  int64           `json:"timestamp"`
+	Recipients []string        `json:"-"` // Player IDs to deliver to; nil means everyone
+	Zone       string          `json:"-"` // Only deliver to players in this zone, when set
 }
 
 // Player represents a player in the game world
 type Player struct {
-	ID        string          `json:"id"`
-	Name      string          `json:"name"`
-	X         float64         `json:"x"`
-	Y         float64         `json:"y"`
-	Class     string          `json:"class"`
-	Health    int             `json:"health"`
-	MaxHealth int             `json:"max_health"`
-	Mana      int             `json:"mana"`
-	MaxMana   int             `json:"max_mana"`
-	Conn      *websocket.Conn `json:"-"` // Only used on server side
-	ConnMutex sync.Mutex      `json:"-"` // Protects WebSocket writes
-	Target    int             `json:"target"`
-	Weapon    *Weapon         `json:"weapon,omitempty"`
-	Strength  int             `json:"strength"`
-	Agility   int             `json:"agility"`
-	Intellect int             `json:"intellect"`
-	Stamina   int             `json:"stamina"`
-	Dead      bool            `json:"dead"`
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	X             float64              `json:"x"`
+	Y             float64              `json:"y"`
+	Class         string               `json:"class"`
+	Level         int                  `json:"level"`
+	XP            int                  `json:"xp"` // Experience earned towards the next level
+	Health        int                  `json:"health"`
+	MaxHealth     int                  `json:"max_health"`
+	Mana          int                  `json:"mana"` // Current amount of the class resource, whatever its type
+	MaxMana       int                  `json:"max_mana"`
+	Resource      ResourceType         `json:"resource"`
+	ComboPoints   int                  `json:"combo_points,omitempty"`
+	ComboTargetID string               `json:"combo_target_id,omitempty"` // Enemy the combo points were built on
+	Conn          *websocket.Conn      `json:"-"`                         // Only used on server side
+	ConnMutex     sync.Mutex           `json:"-"`                         // Protects WebSocket writes
+	Target        int                  `json:"target"`
+	Weapon        *Weapon              `json:"weapon,omitempty"`
+	Strength      int                  `json:"strength"`
+	Agility       int                  `json:"agility"`
+	Intellect     int                  `json:"intellect"`
+	Stamina       int                  `json:"stamina"`
+	Armor         int                  `json:"armor"`
+	Dead          bool                 `json:"dead"`
+	Released      bool                 `json:"released"`             // Dead and waiting to respawn at the graveyard
+	RespawnAt     int64                `json:"respawn_at,omitempty"` // Unix milliseconds
+	Cooldowns     map[string]int64     `json:"cooldowns,omitempty"`  // Ability -> ready at, Unix milliseconds
+	LastSpend     time.Time            `json:"-"`                    // Last time the class resource was spent, for the five second rule
+	Auras         []*Aura              `json:"auras,omitempty"`
+	Derived       DerivedStats         `json:"derived"`
+	Gold          int                  `json:"gold"`
+	Bag           []ItemStack          `json:"bag"`                 // Fixed number of slots; empty slots have no ItemID
+	Equipment     map[EquipSlot]string `json:"equipment,omitempty"` // Slot -> equipped item ID
+	LastLootTurn  time.Time            `json:"-"`                   // When round robin last gave this player a corpse
+	PartyID       string               `json:"party_id,omitempty"`
+	Role          Role                 `json:"-"` // Kept from other clients so staff are not singled out
+	Zone          string               `json:"zone"`
 }
 
 // Weapon represents the weapon equipped by the player or enemy
@@ -59,6 +102,8 @@ type Weapon struct {
 	ID         string        `json:"id"`
 	Name       string        `json:"name"`
 	Damage     int           `json:"damage"`
+	MinDamage  int           `json:"min_damage,omitempty"`
+	MaxDamage  int           `json:"max_damage,omitempty"`
 	Range      int           `json:"range"`
 	WeaponType string        `json:"weapon_type"`
 	Delay      time.Duration `'json:"delay"`
@@ -66,23 +111,334 @@ type Weapon struct {
 
 // Enemy represent a targetable enemy in the game
 type Enemy struct {
-	ID         string             `json:"id"`
-	Name       string             `json:"name"`
-	X          float64            `json:"x"`
-	Y          float64            `json:"y"`
-	EnemyType  string             `json:"enemy_type"`
-	Health     int                `json:"health"`
-	MaxHealth  int                `json:"max_health"`
-	Mana       int                `json:"mana"`
-	MaxMana    int                `json:"max_mana"`
-	TargetID   string             `json:"target_id,omitempty"`
-	LastAttack time.Time          `json:"-"`
-	ThreatList map[string]float64 `json:"-"` // PlayerID -> threat value
-	Weapon     *Weapon            `json:"weapon,omitempty"`
-	Strength   int                `json:"strength"`
-	Agility    int                `json:"agility"`
-	Intellect  int                `json:"intellect"`
-	Stamina    int                `json:"stamina"`
+	ID               string               `json:"id"`
+	Name             string               `json:"name"`
+	X                float64              `json:"x"`
+	Y                float64              `json:"y"`
+	Zone             string               `json:"zone"`
+	EnemyType        string               `json:"enemy_type"`
+	Level            int                  `json:"level"`
+	Boss             bool                 `json:"boss,omitempty"`
+	Health           int                  `json:"health"`
+	MaxHealth        int                  `json:"max_health"`
+	Mana             int                  `json:"mana"`
+	MaxMana          int                  `json:"max_mana"`
+	TargetID         string               `json:"target_id,omitempty"`
+	LastAttack       time.Time            `json:"-"`
+	ThreatList       map[string]float64   `json:"-"` // PlayerID -> threat value
+	TauntedBy        string               `json:"-"` // Player the enemy is forced to attack until TauntEnds
+	TauntEnds        time.Time            `json:"-"`
+	Cooldowns        map[string]time.Time `json:"-"` // Ability -> ready at
+	NextAbilityCheck time.Time            `json:"-"`
+	Phase            int                  `json:"phase,omitempty"` // Current encounter phase for bosses
+	EngagedAt        time.Time            `json:"-"`               // When the boss was pulled; zero while out of combat
+	Enraged          bool                 `json:"enraged,omitempty"`
+	SummonerID       string               `json:"-"` // Boss that summoned this add
+	SpawnX           float64              `json:"-"`
+	SpawnY           float64              `json:"-"`
+	Idle             IdleBehavior         `json:"-"`
+	IdleTarget       *Point               `json:"-"` // Where a wandering enemy is walking
+	IdleUntil        time.Time            `json:"-"` // Idle enemies wait in place until this time
+	WaypointIndex    int                  `json:"-"` // Next waypoint on a patrol route
+	PackID           string               `json:"-"` // Enemies sharing a pack are pulled together
+	AIState          AIState              `json:"ai_state,omitempty"`
+	FleeUntil        time.Time            `json:"-"`
+	HasFled          bool                 `json:"-"` // Enemies flee at most once per fight
+	Weapon           *Weapon              `json:"weapon,omitempty"`
+	Strength         int                  `json:"strength"`
+	Agility          int                  `json:"agility"`
+	Intellect        int                  `json:"intellect"`
+	Stamina          int                  `json:"stamina"`
+	Armor            int                  `json:"armor"`
+	Auras            []*Aura              `json:"auras,omitempty"`
+	Derived          DerivedStats         `json:"derived"`
+}
+
+// IdleKind is what an enemy does while it has nobody to fight
+type IdleKind string
+
+const (
+	IdleStationary IdleKind = "stationary" // Stand at the spawn point
+	IdleWander     IdleKind = "wander"     // Stroll to random points near the spawn point
+	IdlePatrol     IdleKind = "patrol"     // Walk a loop of waypoints
+)
+
+// EnemyPosition is where an enemy has moved to, sent in batches each AI tick
+type EnemyPosition struct {
+	ID string  `json:"id"`
+	X  float64 `json:"x"`
+	Y  float64 `json:"y"`
+}
+
+// AIState is the step of its behavior state machine an enemy is in
+type AIState string
+
+const (
+	AIIdle        AIState = "idle"          // Standing or wandering near the spawn point
+	AIPatrol      AIState = "patrol"        // Walking a waypoint route
+	AICallForHelp AIState = "call_for_help" // Alerting nearby allies on being pulled
+	AIChase       AIState = "chase"         // Closing in on the target
+	AIAttack      AIState = "attack"        // In reach and attacking the target
+	AIFlee        AIState = "flee"          // Running from the target at low health
+	AIEvade       AIState = "evade"         // Giving up the fight and returning to the spawn point
+)
+
+// IdleBehavior configures how an enemy moves around while out of combat
+type IdleBehavior struct {
+	Kind         IdleKind      `json:"kind"`
+	WanderRadius float64       `json:"wander_radius,omitempty"`
+	Waypoints    []Point       `json:"waypoints,omitempty"`
+	Pause        time.Duration `json:"pause,omitempty"` // Wait between wander strolls and at each waypoint
+}
+
+// ResourceType is the pool a class spends to use its abilities
+type ResourceType string
+
+const (
+	ResourceMana   ResourceType = "mana"
+	ResourceRage   ResourceType = "rage"
+	ResourceEnergy ResourceType = "energy"
+)
+
+// DispelType categorizes auras so abilities can remove them selectively
+type DispelType string
+
+const (
+	DispelNone    DispelType = ""
+	DispelMagic   DispelType = "magic"
+	DispelCurse   DispelType = "curse"
+	DispelPoison  DispelType = "poison"
+	DispelDisease DispelType = "disease"
+)
+
+// StatModifiers holds flat stat changes an aura applies per stack
+type StatModifiers struct {
+	Strength  int `json:"strength,omitempty"`
+	Agility   int `json:"agility,omitempty"`
+	Intellect int `json:"intellect,omitempty"`
+	Stamina   int `json:"stamina,omitempty"`
+	Armor     int `json:"armor,omitempty"`
+}
+
+// DerivedStats holds the combat values calculated from a character's attributes
+type DerivedStats struct {
+	MaxHealth   int     `json:"max_health"`
+	AttackPower int     `json:"attack_power"`
+	CritChance  float64 `json:"crit_chance"` // 0.0 - 1.0
+	MaxMana     int     `json:"max_mana"`
+	SpellPower  int     `json:"spell_power"`
+	Armor       int     `json:"armor"`
+	MissChance  float64 `json:"miss_chance"`  // Chance this character's attacks miss
+	DodgeChance float64 `json:"dodge_chance"` // Chance to dodge incoming melee attacks
+	ParryChance float64 `json:"parry_chance"` // Chance to parry incoming melee attacks
+	Level       int     `json:"level"`        // Character level, compared against the opponent's in combat rolls
+}
+
+// HitType is the outcome of a combat roll
+type HitType string
+
+const (
+	HitNormal HitType = "hit"
+	HitCrit   HitType = "crit"
+	HitMiss   HitType = "miss"
+	HitDodge  HitType = "dodge"
+	HitParry  HitType = "parry"
+)
+
+// CombatEventKind distinguishes damage from healing in combat events
+type CombatEventKind string
+
+const (
+	CombatDamage    CombatEventKind = "damage"
+	CombatHeal      CombatEventKind = "heal"
+	CombatDeath     CombatEventKind = "death"  // TargetID died, SourceID is the killer if known
+	CombatThreat    CombatEventKind = "threat" // Enemy SourceID switched its target to TargetID
+	CombatResurrect CombatEventKind = "resurrect"
+)
+
+// CombatEvent describes the outcome of a single attack, heal or periodic tick
+type CombatEvent struct {
+	Kind      CombatEventKind `json:"kind"`
+	SourceID  string          `json:"source_id"`
+	TargetID  string          `json:"target_id"`
+	Ability   string          `json:"ability"`
+	HitType   HitType         `json:"hit_type"`
+	Amount    int             `json:"amount"`
+	Mitigated int             `json:"mitigated,omitempty"` // Damage absorbed by armor
+}
+
+// ThreatEntry is one player's standing on an enemy's threat table
+type ThreatEntry struct {
+	PlayerID string  `json:"player_id"`
+	Threat   float64 `json:"threat"`
+	Percent  float64 `json:"percent"` // Threat relative to the enemy's current target
+	PullAt   float64 `json:"pull_at"` // Percent at which this player would pull aggro
+}
+
+// ThreatTable is the top of an enemy's threat list, sent to the players fighting it
+type ThreatTable struct {
+	EnemyID  string        `json:"enemy_id"`
+	TargetID string        `json:"target_id"`
+	Entries  []ThreatEntry `json:"entries"`
+}
+
+// Aura represents a temporary buff, debuff or periodic effect on a player or enemy
+type Aura struct {
+	ID           string        `json:"id"` // Template ID, e.g. "rend"
+	Name         string        `json:"name"`
+	SourceID     string        `json:"source_id"`
+	Harmful      bool          `json:"harmful"`
+	DispelType   DispelType    `json:"dispel_type,omitempty"`
+	Duration     time.Duration `json:"duration"`
+	ExpiresAt    int64         `json:"expires_at"` // Unix milliseconds
+	Stacks       int           `json:"stacks"`
+	MaxStacks    int           `json:"max_stacks"`
+	Modifiers    StatModifiers `json:"modifiers"`
+	TickInterval time.Duration `json:"tick_interval,omitempty"`
+	TickDamage   int           `json:"tick_damage,omitempty"` // Per stack
+	TickHeal     int           `json:"tick_heal,omitempty"`   // Per stack
+	NextTick     time.Time     `json:"-"`
+}
+
+// Projectile represents a spell or missile travelling toward its target
+type Projectile struct {
+	ID        string  `json:"id"`
+	SourceID  string  `json:"source_id"`
+	TargetID  string  `json:"target_id"`
+	Ability   string  `json:"ability"`
+	Style     string  `json:"style"` // How clients draw it, e.g. "arrow" or "frostbolt"
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Speed     float64 `json:"speed"` // Pixels per second
+	Damage    int     `json:"-"`     // Rolled when launched, applied on impact
+	Mitigated int     `json:"-"`
+	HitType   HitType `json:"-"`
+	Zone      string  `json:"-"`
+}
+
+// GroundEffect is a telegraphed area that damages every player inside it when it lands
+type GroundEffect struct {
+	ID       string        `json:"id"`
+	SourceID string        `json:"source_id"`
+	Ability  string        `json:"ability"`
+	X        float64       `json:"x"`
+	Y        float64       `json:"y"`
+	Radius   float64       `json:"radius"`
+	Warning  time.Duration `json:"warning"` // Time from spawn until the effect lands
+}
+
+// EnemyYell is a line spoken by an enemy, such as a boss announcing a new phase
+type EnemyYell struct {
+	EnemyID string `json:"enemy_id"`
+	Name    string `json:"name"`
+	Text    string `json:"text"`
+}
+
+// ItemStack is a number of one kind of item, such as in a bag or on a corpse
+type ItemStack struct {
+	ItemID string `json:"item_id"`
+	Count  int    `json:"count"`
+}
+
+// XPGain tells a player how much experience a kill earned them
+type XPGain struct {
+	Amount int    `json:"amount"`
+	Source string `json:"source"` // Name of the enemy killed
+}
+
+// Party is a group of players who share kill credit and a chat channel
+type Party struct {
+	ID       string        `json:"id"`
+	LeaderID string        `json:"leader_id"`
+	Members  []PartyMember `json:"members"`   // In the order they joined, leader included
+	LootRule LootRule      `json:"loot_rule"` // How the members share corpses, set by the leader
+}
+
+// PartyMember is a player in a party
+type PartyMember struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PartyInvite asks a player to join the inviter's party
+type PartyInvite struct {
+	FromID   string `json:"from_id"`
+	FromName string `json:"from_name"`
+}
+
+// Role is an account's standing on the server; staff roles unlock admin commands
+type Role string
+
+const (
+	RolePlayer Role = ""
+	RoleGM     Role = "gm"    // Game master: moderates players and the world
+	RoleAdmin  Role = "admin" // Everything a GM can do, plus running the server's content
+)
+
+// AdminCommand is a staff command typed in the chat box, such as "/spawn basic"
+type AdminCommand struct {
+	Command string `json:"command"`
+	Args    string `json:"args,omitempty"`
+}
+
+// ChatChannel decides who hears a chat message
+type ChatChannel string
+
+const (
+	ChatSay     ChatChannel = "say"     // Players close to the speaker
+	ChatYell    ChatChannel = "yell"    // Players in a wider area around the speaker
+	ChatParty   ChatChannel = "party"   // The speaker's party
+	ChatWhisper ChatChannel = "whisper" // A single named player
+	ChatGlobal  ChatChannel = "global"  // Everyone online
+)
+
+// ChatMessage is a line of chat sent by a player
+type ChatMessage struct {
+	Channel    ChatChannel `json:"channel"`
+	Target     string      `json:"target,omitempty"` // Whisper recipient: an ID or name from the client, the name once delivered
+	SenderID   string      `json:"sender_id,omitempty"`
+	SenderName string      `json:"sender_name,omitempty"`
+	Text       string      `json:"text"`
+}
+
+// EquipSlot is where on the body an item is worn
+type EquipSlot string
+
+const (
+	SlotMainHand EquipSlot = "main_hand"
+	SlotHead     EquipSlot = "head"
+	SlotChest    EquipSlot = "chest"
+	SlotLegs     EquipSlot = "legs"
+	SlotFeet     EquipSlot = "feet"
+)
+
+// LootRule decides who may loot the corpses of enemies killed by several players
+type LootRule string
+
+const (
+	LootFreeForAll LootRule = "free_for_all" // Anyone who fought the enemy may loot it
+	LootRoundRobin LootRule = "round_robin"  // Corpses go to each player who fought in turn
+)
+
+// Corpse is a dead enemy that can be looted until it decays
+type Corpse struct {
+	ID        string      `json:"id"` // The ID of the enemy that died
+	Name      string      `json:"name"`
+	EnemyType string      `json:"enemy_type"`
+	X         float64     `json:"x"`
+	Y         float64     `json:"y"`
+	Zone      string      `json:"zone"`
+	Looters   []string    `json:"looters"`    // Players allowed to loot the corpse
+	ExpiresAt int64       `json:"expires_at"` // Unix milliseconds
+	Gold      int         `json:"-"`
+	Items     []ItemStack `json:"-"`
+}
+
+// LootWindow is what is left on a corpse, sent to a player looting it
+type LootWindow struct {
+	CorpseID string      `json:"corpse_id"`
+	Gold     int         `json:"gold"`
+	Items    []ItemStack `json:"items"`
 }
 
 // Wall represents a wall or boundary in the dungeon
@@ -93,7 +449,36 @@ type Wall struct {
 	Height float64 `json:"height"`
 }
 
+// Point is a position in the world
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
 // Room represents a dungeon room with walls
 type Room struct {
-	Walls []Wall `json:"walls"`
+	Zone      string   `json:"zone"` // ID of the zone this room is the map of
+	Name      string   `json:"name"`
+	Walls     []Wall   `json:"walls"`
+	Entry     Point    `json:"entry"`     // Where players arriving in the zone appear
+	Graveyard Point    `json:"graveyard"` // Where released players respawn
+	Portals   []Portal `json:"portals,omitempty"`
+}
+
+// Portal is a region of a room that carries players who walk into it to another zone
+type Portal struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	ToZone string  `json:"to_zone"`
+	Label  string  `json:"label"` // Shown over the portal, e.g. where it leads
+}
+
+// ZoneChange moves the receiving player into a new zone. Everything they knew
+// about the old zone is stale; the new zone's entities follow as separate messages.
+type ZoneChange struct {
+	Room Room    `json:"room"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
 }